@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"afroflix/internal/config"
+)
+
+func TestTTLPolicyPutsConfiguredRulesFirst(t *testing.T) {
+	policy := ttlPolicy(config.CacheConfig{
+		TTL: 2 * time.Minute,
+		TTLRules: []config.TTLRule{
+			{Pattern: "/genre/*/list", TTL: 48 * time.Hour},
+			{Pattern: "/trending/*/*", TTL: 0},
+		},
+	})
+
+	tests := []struct {
+		endpoint string
+		want     time.Duration
+	}{
+		{"/genre/movie/list", 48 * time.Hour},
+		{"/trending/tv/day", 0},
+		// Built-in rules still apply to everything else.
+		{"/movie/550/credits", 6 * time.Hour},
+		{"/configuration", 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := policy.TTL(tt.endpoint); got != tt.want {
+			t.Errorf("TTL(%q) = %v, want %v", tt.endpoint, got, tt.want)
+		}
+	}
+}
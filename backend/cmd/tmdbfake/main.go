@@ -0,0 +1,26 @@
+// Command tmdbfake serves the tmdbtest fixtures over HTTP, so the API can
+// be run without a TMDB token or network access:
+//
+//	go run ./cmd/tmdbfake -listen :8090
+//	go run ./cmd/api -tmdb-token dev -tmdb-base-url http://localhost:8090
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"afroflix/pkg/tmdb/tmdbtest"
+)
+
+func main() {
+	listen := flag.String("listen", ":8090", "address to listen on")
+	latency := flag.Duration("latency", 0, "delay added to every response")
+	flag.Parse()
+
+	h := tmdbtest.NewHandler()
+	h.SetLatency(*latency)
+
+	log.Printf("Fake TMDB listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, h))
+}
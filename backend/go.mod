@@ -3,6 +3,16 @@ module afroflix
 go 1.24.3
 
 require (
-	github.com/gorilla/mux v1.8.1 // indirect
-	github.com/rs/cors v1.11.1 // indirect
+	github.com/alicebob/miniredis/v2 v2.35.0
+	github.com/gorilla/mux v1.8.1
+	github.com/redis/go-redis/v9 v9.9.0
+	github.com/rs/cors v1.11.1
+	golang.org/x/time v0.12.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 )
@@ -0,0 +1,514 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds everything cmd/api needs to start. Values are layered in
+// increasing order of precedence: defaults, config file, environment
+// variables, command-line flags.
+type Config struct {
+	ListenAddr     string        `yaml:"listen_addr"`
+	AllowedOrigins []string      `yaml:"allowed_origins"`
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	TMDB           TMDBConfig    `yaml:"tmdb"`
+	Cache          CacheConfig   `yaml:"cache"`
+}
+
+type TMDBConfig struct {
+	Token   string        `yaml:"token"`
+	BaseURL string        `yaml:"base_url"`
+	Timeout time.Duration `yaml:"timeout"`
+	// Language and Region are the defaults for callers that do not ask
+	// for a locale. An empty region leaves responses unfiltered.
+	Language  string `yaml:"language"`
+	Region    string `yaml:"region"`
+	UserAgent string `yaml:"user_agent"`
+	// ProxyURL routes TMDB traffic through an HTTP proxy. When empty the
+	// standard HTTPS_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string `yaml:"proxy_url"`
+	// Mode selects live traffic, or recording to and replaying from
+	// CassetteDir, which lets the API run offline without a token.
+	Mode        string          `yaml:"mode"`
+	CassetteDir string          `yaml:"cassette_dir"`
+	Retry       RetryConfig     `yaml:"retry"`
+	RateLimit   RateLimitConfig `yaml:"rate_limit"`
+}
+
+type RetryConfig struct {
+	MaxAttempts int           `yaml:"max_attempts"`
+	BaseDelay   time.Duration `yaml:"base_delay"`
+	MaxDelay    time.Duration `yaml:"max_delay"`
+}
+
+// TMDB modes selectable with TMDBConfig.Mode.
+const (
+	TMDBLive   = "live"
+	TMDBRecord = "record"
+	TMDBReplay = "replay"
+)
+
+// Cache backends selectable with CacheConfig.Backend.
+const (
+	CacheMemory = "memory"
+	CacheDisk   = "disk"
+	CacheRedis  = "redis"
+)
+
+type CacheConfig struct {
+	Backend  string        `yaml:"backend"`
+	TTL      time.Duration `yaml:"ttl"`
+	TTLRules []TTLRule     `yaml:"ttl_rules"`
+	// StaleWhileRevalidate and StaleIfError keep entries past their TTL:
+	// served immediately while refreshed in the background, or served when
+	// TMDB is failing, respectively.
+	StaleWhileRevalidate time.Duration `yaml:"stale_while_revalidate"`
+	StaleIfError         time.Duration `yaml:"stale_if_error"`
+	MaxEntries           int           `yaml:"max_entries"`
+	MaxBytes             int64         `yaml:"max_bytes"`
+	CleanupInterval      time.Duration `yaml:"cleanup_interval"`
+	Dir                  string        `yaml:"dir"`
+	Redis                RedisConfig   `yaml:"redis"`
+}
+
+// TTLRule overrides the cache lifetime for TMDB endpoints matching
+// Pattern, e.g. "/genre/*/list", where "*" matches one path segment.
+type TTLRule struct {
+	Pattern string        `yaml:"pattern"`
+	TTL     time.Duration `yaml:"ttl"`
+}
+
+type RedisConfig struct {
+	Addr      string `yaml:"addr"`
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+	DB        int    `yaml:"db"`
+	KeyPrefix string `yaml:"key_prefix"`
+}
+
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// languagePattern matches the language tags TMDB accepts: "en" or "en-US".
+var languagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+func Default() *Config {
+	return &Config{
+		ListenAddr:     ":8080",
+		AllowedOrigins: []string{"http://localhost:3000"},
+		RequestTimeout: 15 * time.Second,
+		TMDB: TMDBConfig{
+			BaseURL:     "https://api.themoviedb.org/3",
+			Timeout:     10 * time.Second,
+			Language:    "en-US",
+			UserAgent:   "tamasha-api",
+			Mode:        TMDBLive,
+			CassetteDir: "cassettes",
+			Retry: RetryConfig{
+				MaxAttempts: 3,
+				BaseDelay:   250 * time.Millisecond,
+				MaxDelay:    5 * time.Second,
+			},
+			RateLimit: RateLimitConfig{
+				RequestsPerSecond: 40,
+				Burst:             20,
+			},
+		},
+		Cache: CacheConfig{
+			Backend:              CacheMemory,
+			TTL:                  5 * time.Minute,
+			MaxEntries:           10000,
+			MaxBytes:             64 << 20,
+			CleanupInterval:      time.Minute,
+			StaleWhileRevalidate: time.Minute,
+			StaleIfError:         time.Hour,
+			Redis: RedisConfig{
+				KeyPrefix: "tamasha:tmdb:",
+			},
+		},
+	}
+}
+
+// Load builds a Config from the process environment and the given
+// command-line arguments (without the program name).
+func Load(args []string) (*Config, error) {
+	return load(args, os.LookupEnv)
+}
+
+func load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	var (
+		configFile = fs.String("config", "", "path to a YAML config file")
+		listenAddr = fs.String("listen", "", "address to listen on, e.g. :8080")
+		origins    = fs.String("allowed-origins", "", "comma-separated list of allowed CORS origins")
+		reqTimeout = fs.Duration("request-timeout", 0, "deadline for handling a single API request")
+		token      = fs.String("tmdb-token", "", "TMDB API read access token")
+		baseURL    = fs.String("tmdb-base-url", "", "TMDB API base URL")
+		timeout    = fs.Duration("tmdb-timeout", 0, "timeout for upstream TMDB requests")
+		tmdbMode   = fs.String("tmdb-mode", "", "TMDB traffic mode: live, record or replay")
+		cacheTTL   = fs.Duration("cache-ttl", 0, "cache lifetime for endpoints without a specific rule")
+		cacheKind  = fs.String("cache-backend", "", "response cache backend: memory, disk or redis")
+	)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(lookupEnv); err != nil {
+		return nil, err
+	}
+
+	// Only flags that were passed explicitly override earlier layers.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen":
+			cfg.ListenAddr = *listenAddr
+		case "allowed-origins":
+			cfg.AllowedOrigins = splitList(*origins)
+		case "request-timeout":
+			cfg.RequestTimeout = *reqTimeout
+		case "tmdb-token":
+			cfg.TMDB.Token = *token
+		case "tmdb-base-url":
+			cfg.TMDB.BaseURL = *baseURL
+		case "tmdb-timeout":
+			cfg.TMDB.Timeout = *timeout
+		case "tmdb-mode":
+			cfg.TMDB.Mode = *tmdbMode
+		case "cache-ttl":
+			cfg.Cache.TTL = *cacheTTL
+		case "cache-backend":
+			cfg.Cache.Backend = *cacheKind
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: reading %s: %w", path, err)
+	}
+	// Reject unknown keys, so a misspelt setting fails the deploy instead
+	// of silently keeping its default.
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) loadEnv(lookupEnv func(string) (string, bool)) error {
+	if v, ok := lookupEnv("PORT"); ok && v != "" {
+		c.ListenAddr = ":" + v
+	}
+	if v, ok := lookupEnv("LISTEN_ADDR"); ok && v != "" {
+		c.ListenAddr = v
+	}
+	if v, ok := lookupEnv("ALLOWED_ORIGINS"); ok && v != "" {
+		c.AllowedOrigins = splitList(v)
+	}
+	if err := envDuration(lookupEnv, "REQUEST_TIMEOUT", &c.RequestTimeout); err != nil {
+		return err
+	}
+	if v, ok := lookupEnv("TMDB_API_TOKEN"); ok && v != "" {
+		c.TMDB.Token = v
+	}
+	if v, ok := lookupEnv("TMDB_BASE_URL"); ok && v != "" {
+		c.TMDB.BaseURL = v
+	}
+	if err := envDuration(lookupEnv, "TMDB_TIMEOUT", &c.TMDB.Timeout); err != nil {
+		return err
+	}
+	if v, ok := lookupEnv("TMDB_LANGUAGE"); ok && v != "" {
+		c.TMDB.Language = v
+	}
+	if v, ok := lookupEnv("TMDB_REGION"); ok && v != "" {
+		c.TMDB.Region = v
+	}
+	if v, ok := lookupEnv("TMDB_USER_AGENT"); ok && v != "" {
+		c.TMDB.UserAgent = v
+	}
+	if v, ok := lookupEnv("TMDB_PROXY_URL"); ok && v != "" {
+		c.TMDB.ProxyURL = v
+	}
+	if v, ok := lookupEnv("TMDB_MODE"); ok && v != "" {
+		c.TMDB.Mode = v
+	}
+	if v, ok := lookupEnv("TMDB_CASSETTE_DIR"); ok && v != "" {
+		c.TMDB.CassetteDir = v
+	}
+	if err := envInt(lookupEnv, "TMDB_RETRY_MAX_ATTEMPTS", &c.TMDB.Retry.MaxAttempts); err != nil {
+		return err
+	}
+	if err := envDuration(lookupEnv, "TMDB_RETRY_BASE_DELAY", &c.TMDB.Retry.BaseDelay); err != nil {
+		return err
+	}
+	if err := envDuration(lookupEnv, "TMDB_RETRY_MAX_DELAY", &c.TMDB.Retry.MaxDelay); err != nil {
+		return err
+	}
+	if err := envFloat(lookupEnv, "TMDB_RATE_LIMIT_RPS", &c.TMDB.RateLimit.RequestsPerSecond); err != nil {
+		return err
+	}
+	if err := envInt(lookupEnv, "TMDB_RATE_LIMIT_BURST", &c.TMDB.RateLimit.Burst); err != nil {
+		return err
+	}
+	if v, ok := lookupEnv("CACHE_BACKEND"); ok && v != "" {
+		c.Cache.Backend = v
+	}
+	if err := envDuration(lookupEnv, "CACHE_TTL", &c.Cache.TTL); err != nil {
+		return err
+	}
+	if v, ok := lookupEnv("CACHE_TTL_RULES"); ok && v != "" {
+		rules, err := parseTTLRules(v)
+		if err != nil {
+			return err
+		}
+		c.Cache.TTLRules = rules
+	}
+	if err := envDuration(lookupEnv, "CACHE_STALE_WHILE_REVALIDATE", &c.Cache.StaleWhileRevalidate); err != nil {
+		return err
+	}
+	if err := envDuration(lookupEnv, "CACHE_STALE_IF_ERROR", &c.Cache.StaleIfError); err != nil {
+		return err
+	}
+	if err := envInt(lookupEnv, "CACHE_MAX_ENTRIES", &c.Cache.MaxEntries); err != nil {
+		return err
+	}
+	if err := envInt64(lookupEnv, "CACHE_MAX_BYTES", &c.Cache.MaxBytes); err != nil {
+		return err
+	}
+	if err := envDuration(lookupEnv, "CACHE_CLEANUP_INTERVAL", &c.Cache.CleanupInterval); err != nil {
+		return err
+	}
+	if v, ok := lookupEnv("CACHE_DIR"); ok && v != "" {
+		c.Cache.Dir = v
+	}
+	if v, ok := lookupEnv("CACHE_REDIS_ADDR"); ok && v != "" {
+		c.Cache.Redis.Addr = v
+	}
+	if v, ok := lookupEnv("CACHE_REDIS_USERNAME"); ok && v != "" {
+		c.Cache.Redis.Username = v
+	}
+	if v, ok := lookupEnv("CACHE_REDIS_PASSWORD"); ok && v != "" {
+		c.Cache.Redis.Password = v
+	}
+	if err := envInt(lookupEnv, "CACHE_REDIS_DB", &c.Cache.Redis.DB); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate reports every problem with the configuration at once so a bad
+// deploy fails fast with a complete message.
+func (c *Config) Validate() error {
+	var errs []error
+
+	// Replaying needs no token, since nothing is sent to TMDB.
+	if c.TMDB.Token == "" && c.TMDB.Mode != TMDBReplay {
+		errs = append(errs, errors.New("tmdb token is required (set TMDB_API_TOKEN or -tmdb-token)"))
+	}
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		errs = append(errs, fmt.Errorf("invalid listen address %q: %w", c.ListenAddr, err))
+	}
+	if len(c.AllowedOrigins) == 0 {
+		errs = append(errs, errors.New("at least one allowed origin is required"))
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid allowed origin %q", origin))
+		}
+	}
+	if c.RequestTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("request timeout must be positive, got %s", c.RequestTimeout))
+	}
+	if u, err := url.Parse(c.TMDB.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("invalid tmdb base url %q", c.TMDB.BaseURL))
+	}
+	if !languagePattern.MatchString(c.TMDB.Language) {
+		errs = append(errs, fmt.Errorf("tmdb language must be an ISO 639-1 code with an optional region, e.g. en-US, got %q", c.TMDB.Language))
+	}
+	if c.TMDB.Region != "" && (len(c.TMDB.Region) != 2 || strings.ToUpper(c.TMDB.Region) != c.TMDB.Region) {
+		errs = append(errs, fmt.Errorf("tmdb region must be an upper-case ISO 3166-1 code, got %q", c.TMDB.Region))
+	}
+	if c.TMDB.ProxyURL != "" {
+		if u, err := url.Parse(c.TMDB.ProxyURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid tmdb proxy url %q", c.TMDB.ProxyURL))
+		}
+	}
+	switch c.TMDB.Mode {
+	case TMDBLive:
+	case TMDBRecord, TMDBReplay:
+		if c.TMDB.CassetteDir == "" {
+			errs = append(errs, fmt.Errorf("tmdb cassette dir is required in %s mode", c.TMDB.Mode))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown tmdb mode %q (want live, record or replay)", c.TMDB.Mode))
+	}
+	if c.TMDB.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("tmdb timeout must be positive, got %s", c.TMDB.Timeout))
+	}
+	if c.TMDB.Retry.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("tmdb retry max_attempts must be at least 1, got %d", c.TMDB.Retry.MaxAttempts))
+	}
+	if c.TMDB.Retry.BaseDelay < 0 || c.TMDB.Retry.MaxDelay < c.TMDB.Retry.BaseDelay {
+		errs = append(errs, fmt.Errorf("tmdb retry delays must satisfy 0 <= base_delay <= max_delay, got %s and %s",
+			c.TMDB.Retry.BaseDelay, c.TMDB.Retry.MaxDelay))
+	}
+	if c.TMDB.RateLimit.RequestsPerSecond < 0 {
+		errs = append(errs, fmt.Errorf("tmdb rate limit must not be negative, got %g", c.TMDB.RateLimit.RequestsPerSecond))
+	}
+	if c.TMDB.RateLimit.RequestsPerSecond > 0 && c.TMDB.RateLimit.Burst < 1 {
+		errs = append(errs, fmt.Errorf("tmdb rate limit burst must be at least 1, got %d", c.TMDB.RateLimit.Burst))
+	}
+	if c.Cache.TTL < 0 {
+		errs = append(errs, fmt.Errorf("cache ttl must not be negative, got %s", c.Cache.TTL))
+	}
+	switch c.Cache.Backend {
+	case CacheMemory:
+	case CacheDisk:
+		if c.Cache.Dir == "" {
+			errs = append(errs, errors.New("cache dir is required for the disk backend"))
+		}
+	case CacheRedis:
+		if c.Cache.Redis.Addr == "" {
+			errs = append(errs, errors.New("cache redis addr is required for the redis backend"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown cache backend %q (want memory, disk or redis)", c.Cache.Backend))
+	}
+	for _, rule := range c.Cache.TTLRules {
+		if _, err := path.Match(rule.Pattern, ""); err != nil || !strings.HasPrefix(rule.Pattern, "/") {
+			errs = append(errs, fmt.Errorf("invalid cache ttl rule pattern %q", rule.Pattern))
+		}
+	}
+	if c.Cache.StaleWhileRevalidate < 0 || c.Cache.StaleIfError < 0 {
+		errs = append(errs, errors.New("cache stale windows must not be negative"))
+	}
+	if c.Cache.MaxEntries < 0 || c.Cache.MaxBytes < 0 {
+		errs = append(errs, errors.New("cache max_entries and max_bytes must not be negative"))
+	}
+	if c.Cache.CleanupInterval < 0 {
+		errs = append(errs, fmt.Errorf("cache cleanup interval must not be negative, got %s", c.Cache.CleanupInterval))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func envDuration(lookupEnv func(string) (string, bool), key string, dst *time.Duration) error {
+	v, ok := lookupEnv(key)
+	if !ok || v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("config: invalid %s: %w", key, err)
+	}
+	*dst = d
+	return nil
+}
+
+func envInt(lookupEnv func(string) (string, bool), key string, dst *int) error {
+	v, ok := lookupEnv(key)
+	if !ok || v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("config: invalid %s: %w", key, err)
+	}
+	*dst = n
+	return nil
+}
+
+func envInt64(lookupEnv func(string) (string, bool), key string, dst *int64) error {
+	v, ok := lookupEnv(key)
+	if !ok || v == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return fmt.Errorf("config: invalid %s: %w", key, err)
+	}
+	*dst = n
+	return nil
+}
+
+func envFloat(lookupEnv func(string) (string, bool), key string, dst *float64) error {
+	v, ok := lookupEnv(key)
+	if !ok || v == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return fmt.Errorf("config: invalid %s: %w", key, err)
+	}
+	*dst = f
+	return nil
+}
+
+// parseTTLRules parses "pattern=ttl" pairs separated by commas, e.g.
+// "/genre/*/list=24h,/trending/*/*=10m".
+func parseTTLRules(s string) ([]TTLRule, error) {
+	var rules []TTLRule
+	for _, part := range splitList(s) {
+		pattern, ttl, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("config: invalid CACHE_TTL_RULES entry %q (want pattern=ttl)", part)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(ttl))
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid CACHE_TTL_RULES entry %q: %w", part, err)
+		}
+		rules = append(rules, TTLRule{Pattern: strings.TrimSpace(pattern), TTL: d})
+	}
+	return rules, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
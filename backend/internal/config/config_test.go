@@ -0,0 +1,211 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// env returns a lookupEnv that sees only vars.
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+listen_addr: ":7000"
+request_timeout: 20s
+tmdb:
+  token: file-token
+  language: fr-FR
+  timeout: 3s
+cache:
+  ttl: 10m
+`)
+
+	tests := []struct {
+		name  string
+		args  []string
+		env   map[string]string
+		check func(*Config) string
+	}{
+		{"file over defaults", []string{"-config", path}, nil, func(c *Config) string {
+			if c.ListenAddr != ":7000" || c.TMDB.Token != "file-token" || c.TMDB.Language != "fr-FR" {
+				return "file values not applied"
+			}
+			if c.TMDB.UserAgent != "tamasha-api" || c.Cache.Backend != CacheMemory {
+				return "defaults missing for keys the file leaves out"
+			}
+			return ""
+		}},
+		{"file from CONFIG_FILE", nil, map[string]string{"CONFIG_FILE": path}, func(c *Config) string {
+			if c.TMDB.Token != "file-token" {
+				return "CONFIG_FILE not read"
+			}
+			return ""
+		}},
+		{"env over file", []string{"-config", path}, map[string]string{
+			"TMDB_API_TOKEN": "env-token", "TMDB_TIMEOUT": "4s", "CACHE_TTL": "",
+		}, func(c *Config) string {
+			if c.TMDB.Token != "env-token" || c.TMDB.Timeout != 4*time.Second {
+				return "env values not applied"
+			}
+			if c.Cache.TTL != 10*time.Minute {
+				return "empty env var overrode the file"
+			}
+			return ""
+		}},
+		{"flag over env", []string{"-config", path, "-tmdb-token", "flag-token", "-cache-ttl", "1m"}, map[string]string{
+			"TMDB_API_TOKEN": "env-token", "CACHE_TTL": "2m",
+		}, func(c *Config) string {
+			if c.TMDB.Token != "flag-token" || c.Cache.TTL != time.Minute {
+				return "flag values not applied"
+			}
+			if c.RequestTimeout != 20*time.Second {
+				return "unset flag overrode the file"
+			}
+			return ""
+		}},
+		{"PORT", nil, map[string]string{"TMDB_API_TOKEN": "t", "PORT": "9000"}, func(c *Config) string {
+			if c.ListenAddr != ":9000" {
+				return "PORT not applied"
+			}
+			return ""
+		}},
+		{"LISTEN_ADDR over PORT", nil, map[string]string{
+			"TMDB_API_TOKEN": "t", "PORT": "9000", "LISTEN_ADDR": "127.0.0.1:9001",
+		}, func(c *Config) string {
+			if c.ListenAddr != "127.0.0.1:9001" {
+				return "LISTEN_ADDR did not win over PORT"
+			}
+			return ""
+		}},
+		{"listen flag over LISTEN_ADDR", []string{"-listen", ":9002"}, map[string]string{
+			"TMDB_API_TOKEN": "t", "LISTEN_ADDR": ":9001",
+		}, func(c *Config) string {
+			if c.ListenAddr != ":9002" {
+				return "-listen did not win over LISTEN_ADDR"
+			}
+			return ""
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(tt.args, env(tt.env))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if msg := tt.check(cfg); msg != "" {
+				t.Fatalf("%s: %+v", msg, cfg)
+			}
+		})
+	}
+}
+
+func TestLoadFileDurations(t *testing.T) {
+	path := writeFile(t, `
+tmdb:
+  token: t
+  retry:
+    base_delay: 100ms
+    max_delay: 1m30s
+cache:
+  stale_while_revalidate: 2m
+  ttl_rules:
+    - pattern: "/genre/*/list"
+      ttl: 48h
+`)
+	cfg, err := load([]string{"-config", path}, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TMDB.Retry.BaseDelay != 100*time.Millisecond || cfg.TMDB.Retry.MaxDelay != 90*time.Second {
+		t.Fatalf("retry = %+v", cfg.TMDB.Retry)
+	}
+	if cfg.Cache.StaleWhileRevalidate != 2*time.Minute {
+		t.Fatalf("stale_while_revalidate = %s", cfg.Cache.StaleWhileRevalidate)
+	}
+	want := TTLRule{Pattern: "/genre/*/list", TTL: 48 * time.Hour}
+	if len(cfg.Cache.TTLRules) != 1 || cfg.Cache.TTLRules[0] != want {
+		t.Fatalf("ttl_rules = %+v", cfg.Cache.TTLRules)
+	}
+
+	path = writeFile(t, "tmdb:\n  timeout: soon\n")
+	if _, err := load([]string{"-config", path}, env(nil)); err == nil {
+		t.Fatal("expected an error for an unparsable duration")
+	}
+}
+
+func TestLoadFileRejectsUnknownKeys(t *testing.T) {
+	path := writeFile(t, "tmdb:\n  token: t\n  langauge: fr-FR\n")
+	_, err := load([]string{"-config", path}, env(nil))
+	if err == nil || !strings.Contains(err.Error(), "langauge") {
+		t.Fatalf("err = %v, want one naming the unknown key", err)
+	}
+
+	// The example file must keep loading as the schema changes.
+	if _, err := load([]string{"-config", "../../config.example.yaml"}, env(map[string]string{"TMDB_API_TOKEN": "t"})); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadRejectsBadEnv(t *testing.T) {
+	for _, vars := range []map[string]string{
+		{"TMDB_TIMEOUT": "10"},
+		{"TMDB_RATE_LIMIT_BURST": "many"},
+		{"TMDB_LANGUAGE": "fr_FR"},
+		{"CACHE_TTL_RULES": "/genre/*/list"},
+	} {
+		vars["TMDB_API_TOKEN"] = "t"
+		if _, err := load(nil, env(vars)); err == nil {
+			t.Errorf("expected an error for %v", vars)
+		}
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.ListenAddr = "8080"
+	cfg.TMDB.BaseURL = "ftp://example.com"
+	cfg.TMDB.Language = "english"
+	cfg.TMDB.Region = "ke"
+	cfg.Cache.Backend = "memcached"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		t.Fatalf("error %v does not wrap a joined error", err)
+	}
+	if n := len(joined.Unwrap()); n != 6 {
+		t.Fatalf("got %d errors, want 6:\n%v", n, err)
+	}
+	for _, want := range []string{"token", "listen address", "base url", "language", "region", "cache backend"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+
+	cfg = Default()
+	cfg.TMDB.Mode = TMDBReplay
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("replay mode without a token: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GetCertification serves /api/certification/{type}/{id}?country=, a
+// title's age rating in one country, which defaults to the request's
+// region.
+func (h *Handler) GetCertification(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	mediaType, id, ok := titlePath(r, "/api/certification/")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+
+	p := queryParser{q: r.URL.Query()}
+	country := strings.ToUpper(p.code("country"))
+	if p.bad != "" {
+		h.sendError(w, http.StatusBadRequest, "invalid country parameter")
+		return
+	}
+	if country == "" {
+		country = loc.Region
+	}
+	if country == "" {
+		h.sendError(w, http.StatusBadRequest, "country parameter is required")
+		return
+	}
+
+	var (
+		certification interface{}
+		err           error
+	)
+
+	switch mediaType {
+	case "movie":
+		certification, err = h.tmdbClient.GetMovieCertification(r.Context(), id, country)
+	case "tv":
+		certification, err = h.tmdbClient.GetTVCertification(r.Context(), id, country)
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch certification")
+		return
+	}
+
+	h.sendResult(w, r, certification)
+}
+
+// GetCertifications serves /api/certifications/{type}, every country's
+// certifications with their meanings.
+func (h *Handler) GetCertifications(w http.ResponseWriter, r *http.Request) {
+	var (
+		certifications interface{}
+		err            error
+	)
+
+	switch strings.TrimPrefix(r.URL.Path, "/api/certifications/") {
+	case "movie":
+		certifications, err = h.tmdbClient.GetMovieCertifications(r.Context())
+	case "tv":
+		certifications, err = h.tmdbClient.GetTVCertifications(r.Context())
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch certifications")
+		return
+	}
+
+	h.sendResult(w, r, certifications)
+}
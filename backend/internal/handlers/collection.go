@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+func (h *Handler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(r, "/api/collection/", "")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid collection ID")
+		return
+	}
+
+	collection, err := h.tmdbClient.GetCollection(r.Context(), id, loc)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch collection")
+		return
+	}
+
+	h.sendResult(w, r, collection)
+}
+
+func (h *Handler) GetCollectionImages(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(r, "/api/collection/", "/images")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid collection ID")
+		return
+	}
+
+	images, err := h.tmdbClient.GetCollectionImages(r.Context(), id, loc)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch collection images")
+		return
+	}
+
+	h.sendResult(w, r, images)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"afroflix/pkg/tmdb"
+)
+
+// GetDiscover serves /api/discover/{type}, passing filters through to
+// discover under TMDB's own names, such as with_genres=28,12,
+// vote_average.gte=7 or primary_release_date.gte=2020-01-01 (from for
+// short). Filters this API does not support are rejected rather than
+// silently dropped.
+func (h *Handler) GetDiscover(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	mediaType := strings.TrimPrefix(r.URL.Path, "/api/discover/")
+	if mediaType != "movie" && mediaType != "tv" {
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	opts, problem := discoverOptions(r.URL.Query(), mediaType)
+	if problem != "" {
+		h.sendError(w, http.StatusBadRequest, problem)
+		return
+	}
+	opts.WatchRegion = cmp.Or(opts.WatchRegion, loc.Region)
+	opts.CertificationCountry = cmp.Or(opts.CertificationCountry, loc.Region)
+	if err := opts.Validate(mediaType); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid filters: "+strings.TrimPrefix(err.Error(), "tmdb: "))
+		return
+	}
+
+	// TMDB quietly returns nothing for a certification the country does
+	// not use, which a parental filter cannot tell from "nothing suitable".
+	if opts.MaxCertification != "" {
+		var (
+			certs *tmdb.CertificationsResponse
+			err   error
+		)
+		if mediaType == "movie" {
+			certs, err = h.tmdbClient.GetMovieCertifications(r.Context())
+		} else {
+			certs, err = h.tmdbClient.GetTVCertifications(r.Context())
+		}
+		if err != nil {
+			h.sendUpstreamError(w, err, "failed to fetch certifications")
+			return
+		}
+		cert, ok := certs.Lookup(opts.CertificationCountry, opts.MaxCertification)
+		if !ok {
+			h.sendError(w, http.StatusBadRequest, fmt.Sprintf("invalid filters: certification %q is not used for %s in %s",
+				opts.MaxCertification, mediaType, opts.CertificationCountry))
+			return
+		}
+		opts.MaxCertification = cert.Certification
+	}
+
+	var (
+		results *tmdb.TrendingResponse
+		err     error
+	)
+	if mediaType == "movie" {
+		results, err = h.tmdbClient.DiscoverMovies(r.Context(), opts, loc)
+	} else {
+		results, err = h.tmdbClient.DiscoverTV(r.Context(), opts, loc)
+	}
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch content")
+		return
+	}
+
+	h.sendResult(w, r, results)
+}
+
+// discoverOptions reads the discover filters for mediaType from q. It
+// returns a message naming the first malformed or unsupported parameter if
+// there is one.
+func discoverOptions(q url.Values, mediaType string) (tmdb.DiscoverOptions, string) {
+	date := "primary_release_date"
+	if mediaType == "tv" {
+		date = "first_air_date"
+	}
+
+	p := queryParser{q: q}
+	opts := tmdb.DiscoverOptions{
+		Genres:               p.ids("with_genres"),
+		WithoutGenres:        p.ids("without_genres").IDs,
+		From:                 p.date(p.either("from", date+".gte")),
+		To:                   p.date(p.either("to", date+".lte")),
+		MinVoteAverage:       p.float("vote_average.gte"),
+		MaxVoteAverage:       p.float("vote_average.lte"),
+		MinVoteCount:         p.int("vote_count.gte"),
+		MaxVoteCount:         p.int("vote_count.lte"),
+		MinRuntime:           p.int(p.either("with_runtime.gte", "runtime.gte")),
+		MaxRuntime:           p.int(p.either("with_runtime.lte", "runtime.lte")),
+		OriginalLanguage:     strings.ToLower(p.code("with_original_language")),
+		OriginCountry:        strings.ToUpper(p.code("with_origin_country")),
+		Keywords:             p.ids("with_keywords"),
+		Companies:            p.ids("with_companies"),
+		WatchProviders:       p.ids("with_watch_providers"),
+		WatchRegion:          strings.ToUpper(p.code("watch_region")),
+		MaxCertification:     p.get("certification.lte"),
+		CertificationCountry: strings.ToUpper(p.code("certification_country")),
+		SortBy:               p.get("sort_by"),
+		Page:                 p.page(),
+	}
+	for _, m := range p.list("with_watch_monetization_types") {
+		opts.Monetization = append(opts.Monetization, tmdb.Monetization(m))
+	}
+	if p.bad != "" {
+		return opts, "invalid " + p.bad + " parameter"
+	}
+	if key := p.unknown("language", "region"); key != "" {
+		return opts, "unsupported " + key + " parameter"
+	}
+	return opts, ""
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"afroflix/pkg/tmdb"
+)
+
+type errorResponse struct {
+	Error      string `json:"error"`
+	Code       string `json:"code"`
+	Status     int    `json:"status"`
+	RetryAfter int    `json:"retry_after,omitempty"`
+}
+
+var errorCodes = map[int]string{
+	http.StatusBadRequest:          "bad_request",
+	http.StatusUnauthorized:        "unauthorized",
+	http.StatusNotFound:            "not_found",
+	http.StatusTooManyRequests:     "rate_limited",
+	http.StatusInternalServerError: "internal_error",
+	http.StatusBadGateway:          "upstream_error",
+	http.StatusGatewayTimeout:      "upstream_timeout",
+}
+
+// sendUpstreamError maps an error from the TMDB client onto the status the
+// caller should see, so a missing title is a 404 rather than a 500.
+func (h *Handler) sendUpstreamError(w http.ResponseWriter, err error, message string) {
+	var (
+		apiErr    *tmdb.APIError
+		netErr    *tmdb.NetworkError
+		decodeErr *tmdb.DecodeError
+	)
+
+	switch {
+	case errors.As(err, &apiErr):
+		switch {
+		case errors.Is(apiErr, tmdb.ErrNotFound):
+			h.sendError(w, http.StatusNotFound, message)
+		case errors.Is(apiErr, tmdb.ErrUnauthorized):
+			h.sendError(w, http.StatusUnauthorized, message)
+		case errors.Is(apiErr, tmdb.ErrRateLimited):
+			resp := errorResponse{
+				Error:      message,
+				Code:       errorCodes[http.StatusTooManyRequests],
+				Status:     http.StatusTooManyRequests,
+				RetryAfter: int(apiErr.RetryAfter.Seconds()),
+			}
+			w.Header().Set("Cache-Control", "no-store")
+			if resp.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(resp.RetryAfter))
+			}
+			h.sendJSON(w, resp.Status, resp)
+		case apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity:
+			h.sendError(w, http.StatusBadRequest, message)
+		default:
+			h.sendError(w, http.StatusBadGateway, message)
+		}
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			h.sendError(w, http.StatusGatewayTimeout, message)
+		} else {
+			h.sendError(w, http.StatusBadGateway, message)
+		}
+	case errors.As(err, &decodeErr):
+		h.sendError(w, http.StatusBadGateway, message)
+	default:
+		h.sendError(w, http.StatusInternalServerError, message)
+	}
+}
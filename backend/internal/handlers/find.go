@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"afroflix/pkg/tmdb"
+)
+
+// findSources maps the source names used in /api/find paths onto TMDB's.
+var findSources = map[string]tmdb.ExternalSource{
+	"imdb":      tmdb.SourceIMDb,
+	"tvdb":      tmdb.SourceTVDB,
+	"tvrage":    tmdb.SourceTVRage,
+	"wikidata":  tmdb.SourceWikidata,
+	"facebook":  tmdb.SourceFacebook,
+	"instagram": tmdb.SourceInstagram,
+	"twitter":   tmdb.SourceTwitter,
+	"tiktok":    tmdb.SourceTikTok,
+	"youtube":   tmdb.SourceYouTube,
+}
+
+// GetFind serves /api/find/{source}/{external_id}, the TMDB items with an
+// ID from another site, e.g. /api/find/imdb/tt0137523. It is a 404 when
+// nothing matches, so deep links to unknown IDs fail like unknown titles.
+func (h *Handler) GetFind(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	name, externalID, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/find/"), "/")
+	source, ok := findSources[name]
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid external source")
+		return
+	}
+	if !source.Valid(externalID) {
+		h.sendError(w, http.StatusBadRequest, "invalid external ID")
+		return
+	}
+
+	found, err := h.tmdbClient.Find(r.Context(), source, externalID, loc)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to find external ID")
+		return
+	}
+	if found.Empty() {
+		h.sendError(w, http.StatusNotFound, "nothing found for external ID")
+		return
+	}
+
+	h.sendResult(w, r, found)
+}
+
+// GetExternalIDs serves /api/external_ids/{type}/{id}, a movie's, show's
+// or person's IDs on IMDb, TVDB, Wikidata and social media.
+func (h *Handler) GetExternalIDs(w http.ResponseWriter, r *http.Request) {
+	mediaType, id, ok := titlePath(r, "/api/external_ids/")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+
+	var (
+		ids *tmdb.ExternalIDs
+		err error
+	)
+
+	switch mediaType {
+	case "movie":
+		ids, err = h.tmdbClient.GetMovieExternalIDs(r.Context(), id)
+	case "tv":
+		ids, err = h.tmdbClient.GetTVExternalIDs(r.Context(), id)
+	case "person":
+		ids, err = h.tmdbClient.GetPersonExternalIDs(r.Context(), id)
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch external IDs")
+		return
+	}
+
+	h.sendResult(w, r, ids)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +10,10 @@ import (
 	"afroflix/pkg/tmdb"
 )
 
+// StaleHeader is set on responses built from cached TMDB data that is past
+// its TTL, because TMDB was being refreshed or was failing.
+const StaleHeader = "X-Data-Stale"
+
 type Handler struct {
 	tmdbClient *tmdb.Client
 }
@@ -25,11 +30,49 @@ func (h *Handler) sendJSON(w http.ResponseWriter, status int, data interface{})
 	json.NewEncoder(w).Encode(data)
 }
 
+// sendResult writes a successful response, letting browsers and proxies
+// cache it for as long as the underlying TMDB data stays fresh, and
+// flagging it when some of that data is past its TTL.
+func (h *Handler) sendResult(w http.ResponseWriter, r *http.Request, data interface{}) {
+	if info := tmdb.ResponseInfoFrom(r.Context()); info != nil {
+		if maxAge, ok := info.MaxAge(); ok {
+			w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+		}
+		if info.Stale() {
+			w.Header().Set(StaleHeader, "true")
+		}
+	}
+	// The response depends on the caller's language preference.
+	w.Header().Add("Vary", "Accept-Language")
+	h.sendJSON(w, http.StatusOK, data)
+}
+
+// locale reads the caller's language and region, answering 400 itself if
+// they are malformed.
+func (h *Handler) locale(w http.ResponseWriter, r *http.Request) (tmdb.Locale, bool) {
+	loc, err := requestLocale(r)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, err.Error())
+		return tmdb.Locale{}, false
+	}
+	return loc, true
+}
+
 func (h *Handler) sendError(w http.ResponseWriter, status int, message string) {
-	h.sendJSON(w, status, map[string]string{"error": message})
+	w.Header().Set("Cache-Control", "no-store")
+	h.sendJSON(w, status, errorResponse{
+		Error:  message,
+		Code:   errorCodes[status],
+		Status: status,
+	})
 }
 
 func (h *Handler) GetTrendingMovies(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	timeWindow := r.URL.Query().Get("time_window")
 	if timeWindow == "" {
 		timeWindow = "week"
@@ -40,16 +83,21 @@ func (h *Handler) GetTrendingMovies(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	movies, err := h.tmdbClient.GetTrendingMovies(timeWindow)
+	movies, err := h.tmdbClient.GetTrendingMovies(r.Context(), timeWindow, loc)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch trending movies")
+		h.sendUpstreamError(w, err, "failed to fetch trending movies")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, movies)
+	h.sendResult(w, r, movies)
 }
 
 func (h *Handler) GetTrendingTV(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	timeWindow := r.URL.Query().Get("time_window")
 	if timeWindow == "" {
 		timeWindow = "week"
@@ -60,42 +108,48 @@ func (h *Handler) GetTrendingTV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shows, err := h.tmdbClient.GetTrendingTV(timeWindow)
+	shows, err := h.tmdbClient.GetTrendingTV(r.Context(), timeWindow, loc)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch trending TV shows")
+		h.sendUpstreamError(w, err, "failed to fetch trending TV shows")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, shows)
+	h.sendResult(w, r, shows)
 }
 
 func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	query := r.URL.Query().Get("query")
 	if query == "" {
 		h.sendError(w, http.StatusBadRequest, "query parameter is required")
 		return
 	}
 
-	page := 1
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		var err error
-		page, err = strconv.Atoi(pageStr)
-		if err != nil || page < 1 {
-			h.sendError(w, http.StatusBadRequest, "invalid page parameter")
-			return
-		}
+	page, ok := pageParam(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid page parameter")
+		return
 	}
 
-	results, err := h.tmdbClient.SearchMulti(query, page)
+	results, err := h.tmdbClient.SearchMulti(r.Context(), query, page, loc)
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to search")
+		h.sendUpstreamError(w, err, "failed to search")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, results)
+	h.sendResult(w, r, results)
 }
 
 func (h *Handler) GetDetails(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/details/")
 	parts := strings.Split(mediaType, "/")
 	if len(parts) != 2 {
@@ -106,6 +160,12 @@ func (h *Handler) GetDetails(w http.ResponseWriter, r *http.Request) {
 	mediaType = parts[0]
 	id := parts[1]
 
+	include, ok := includes(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid include parameter")
+		return
+	}
+
 	var (
 		details interface{}
 		err     error
@@ -113,23 +173,61 @@ func (h *Handler) GetDetails(w http.ResponseWriter, r *http.Request) {
 
 	switch mediaType {
 	case "movie":
-		details, err = h.tmdbClient.GetMovieDetails(id)
+		details, err = h.tmdbClient.GetMovieDetails(r.Context(), id, loc, include...)
 	case "tv":
-		details, err = h.tmdbClient.GetTVDetails(id)
+		details, err = h.tmdbClient.GetTVDetails(r.Context(), id, loc, include...)
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
 		return
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch details")
+		h.sendUpstreamError(w, err, "failed to fetch details")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, details)
+	h.sendResult(w, r, details)
+}
+
+// pathID extracts the ID from prefix{id}suffix, e.g. /api/person/{id}/credits,
+// reporting whether it is a valid TMDB ID.
+func pathID(r *http.Request, prefix, suffix string) (string, bool) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix), suffix)
+	n, err := strconv.Atoi(id)
+	return id, err == nil && n > 0
+}
+
+// titlePath splits prefix{type}/{id}, e.g. /api/similar/movie/550, reporting
+// whether the ID is a valid TMDB ID. The type is checked by the caller.
+func titlePath(r *http.Request, prefix string) (mediaType, id string, ok bool) {
+	mediaType, id, found := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	n, err := strconv.Atoi(id)
+	return mediaType, id, found && err == nil && n > 0
+}
+
+// includes parses the comma-separated include query parameter naming the
+// sections to return along with a title's details, e.g.
+// ?include=credits,videos.
+func includes(r *http.Request) ([]string, bool) {
+	v := r.URL.Query().Get("include")
+	if v == "" {
+		return nil, true
+	}
+	sections := strings.Split(v, ",")
+	for _, s := range sections {
+		if !tmdb.ValidAppend(s) {
+			return nil, false
+		}
+	}
+	return sections, true
 }
 
 func (h *Handler) GetCredits(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/credits/")
 	parts := strings.Split(mediaType, "/")
 	if len(parts) != 2 {
@@ -147,23 +245,28 @@ func (h *Handler) GetCredits(w http.ResponseWriter, r *http.Request) {
 
 	switch mediaType {
 	case "movie":
-		credits, err = h.tmdbClient.GetMovieCredits(id)
+		credits, err = h.tmdbClient.GetMovieCredits(r.Context(), id, loc)
 	case "tv":
-		credits, err = h.tmdbClient.GetTVCredits(id)
+		credits, err = h.tmdbClient.GetTVCredits(r.Context(), id, loc)
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
 		return
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch credits")
+		h.sendUpstreamError(w, err, "failed to fetch credits")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, credits)
+	h.sendResult(w, r, credits)
 }
 
 func (h *Handler) GetGenres(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/genres/")
 
 	var (
@@ -173,23 +276,28 @@ func (h *Handler) GetGenres(w http.ResponseWriter, r *http.Request) {
 
 	switch mediaType {
 	case "movie":
-		genres, err = h.tmdbClient.GetMovieGenres()
+		genres, err = h.tmdbClient.GetMovieGenres(r.Context(), loc)
 	case "tv":
-		genres, err = h.tmdbClient.GetTVGenres()
+		genres, err = h.tmdbClient.GetTVGenres(r.Context(), loc)
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
 		return
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch genres")
+		h.sendUpstreamError(w, err, "failed to fetch genres")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, genres)
+	h.sendResult(w, r, genres)
 }
 
 func (h *Handler) GetByGenre(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/discover/")
 	parts := strings.Split(mediaType, "/")
 	if len(parts) != 2 {
@@ -198,35 +306,33 @@ func (h *Handler) GetByGenre(w http.ResponseWriter, r *http.Request) {
 	}
 
 	mediaType = parts[0]
+	popular := parts[1] == "popular"
 	genreID, err := strconv.Atoi(parts[1])
-	if err != nil {
+	if err != nil && !popular {
 		h.sendError(w, http.StatusBadRequest, "invalid genre ID")
 		return
 	}
 
-	page := 1
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		page, err = strconv.Atoi(pageStr)
-		if err != nil || page < 1 {
-			h.sendError(w, http.StatusBadRequest, "invalid page parameter")
-			return
-		}
+	page, ok := pageParam(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid page parameter")
+		return
 	}
 
 	var results interface{}
 
 	switch mediaType {
 	case "movie":
-		if parts[1] == "popular" {
-			results, err = h.tmdbClient.GetPopularMovies(page)
+		if popular {
+			results, err = h.tmdbClient.GetPopularMovies(r.Context(), page, loc)
 		} else {
-			results, err = h.tmdbClient.GetMoviesByGenre(genreID, page)
+			results, err = h.tmdbClient.GetMoviesByGenre(r.Context(), genreID, page, loc)
 		}
 	case "tv":
-		if parts[1] == "popular" {
-			results, err = h.tmdbClient.GetPopularTV(page)
+		if popular {
+			results, err = h.tmdbClient.GetPopularTV(r.Context(), page, loc)
 		} else {
-			results, err = h.tmdbClient.GetTVByGenre(genreID, page)
+			results, err = h.tmdbClient.GetTVByGenre(r.Context(), genreID, page, loc)
 		}
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
@@ -234,14 +340,19 @@ func (h *Handler) GetByGenre(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch content")
+		h.sendUpstreamError(w, err, "failed to fetch content")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, results)
+	h.sendResult(w, r, results)
 }
 
 func (h *Handler) GetVideos(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/videos/")
 	parts := strings.Split(mediaType, "/")
 	if len(parts) != 2 {
@@ -259,23 +370,28 @@ func (h *Handler) GetVideos(w http.ResponseWriter, r *http.Request) {
 
 	switch mediaType {
 	case "movie":
-		videos, err = h.tmdbClient.GetMovieVideos(id)
+		videos, err = h.tmdbClient.GetMovieVideos(r.Context(), id, loc)
 	case "tv":
-		videos, err = h.tmdbClient.GetTVVideos(id)
+		videos, err = h.tmdbClient.GetTVVideos(r.Context(), id, loc)
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
 		return
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch videos")
+		h.sendUpstreamError(w, err, "failed to fetch videos")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, videos)
+	h.sendResult(w, r, videos)
 }
 
 func (h *Handler) GetImages(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/images/")
 	parts := strings.Split(mediaType, "/")
 	if len(parts) != 2 {
@@ -293,23 +409,28 @@ func (h *Handler) GetImages(w http.ResponseWriter, r *http.Request) {
 
 	switch mediaType {
 	case "movie":
-		images, err = h.tmdbClient.GetMovieImages(id)
+		images, err = h.tmdbClient.GetMovieImages(r.Context(), id, loc)
 	case "tv":
-		images, err = h.tmdbClient.GetTVImages(id)
+		images, err = h.tmdbClient.GetTVImages(r.Context(), id, loc)
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
 		return
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch images")
+		h.sendUpstreamError(w, err, "failed to fetch images")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, images)
+	h.sendResult(w, r, images)
 }
 
 func (h *Handler) GetWatchProviders(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/watch/providers/")
 	parts := strings.Split(mediaType, "/")
 	if len(parts) != 2 {
@@ -322,28 +443,33 @@ func (h *Handler) GetWatchProviders(w http.ResponseWriter, r *http.Request) {
 
 	var (
 		providers interface{}
-		err      error
+		err       error
 	)
 
 	switch mediaType {
 	case "movie":
-		providers, err = h.tmdbClient.GetMovieWatchProviders(id)
+		providers, err = h.tmdbClient.GetMovieWatchProviders(r.Context(), id, loc)
 	case "tv":
-		providers, err = h.tmdbClient.GetTVWatchProviders(id)
+		providers, err = h.tmdbClient.GetTVWatchProviders(r.Context(), id, loc)
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
 		return
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch watch providers")
+		h.sendUpstreamError(w, err, "failed to fetch watch providers")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, providers)
+	h.sendResult(w, r, providers)
 }
 
 func (h *Handler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
 	mediaType := strings.TrimPrefix(r.URL.Path, "/api/recommendations/")
 	parts := strings.Split(mediaType, "/")
 	if len(parts) != 2 {
@@ -356,23 +482,23 @@ func (h *Handler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
 
 	var (
 		recommendations interface{}
-		err            error
+		err             error
 	)
 
 	switch mediaType {
 	case "movie":
-		recommendations, err = h.tmdbClient.GetMovieRecommendations(id)
+		recommendations, err = h.tmdbClient.GetMovieRecommendations(r.Context(), id, loc)
 	case "tv":
-		recommendations, err = h.tmdbClient.GetTVRecommendations(id)
+		recommendations, err = h.tmdbClient.GetTVRecommendations(r.Context(), id, loc)
 	default:
 		h.sendError(w, http.StatusBadRequest, "invalid media type")
 		return
 	}
 
 	if err != nil {
-		h.sendError(w, http.StatusInternalServerError, "failed to fetch recommendations")
+		h.sendUpstreamError(w, err, "failed to fetch recommendations")
 		return
 	}
 
-	h.sendJSON(w, http.StatusOK, recommendations)
-} 
\ No newline at end of file
+	h.sendResult(w, r, recommendations)
+}
@@ -0,0 +1,529 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"afroflix/pkg/tmdb"
+	"afroflix/pkg/tmdb/tmdbtest"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestHandler returns a Handler backed by a fresh fake TMDB. Retries are
+// disabled so injected failures reach the handler unchanged.
+func newTestHandler(t *testing.T, token string, opts ...tmdb.Option) (*Handler, *tmdbtest.Server) {
+	t.Helper()
+	srv := tmdbtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	opts = append([]tmdb.Option{
+		tmdb.WithBaseURL(srv.URL),
+		tmdb.WithRetryPolicy(tmdb.RetryPolicy{MaxAttempts: 1}),
+	}, opts...)
+	client := tmdb.NewClient(token, opts...)
+	t.Cleanup(func() { client.Close() })
+	return NewHandler(client), srv
+}
+
+// serve runs target through handler behind the same middleware as cmd/api.
+func serve(handler http.HandlerFunc, target string, timeout time.Duration) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	Timeout(timeout)(TrackUpstream(handler)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestHandlersServeFixtures(t *testing.T) {
+	h, _ := newTestHandler(t, "token")
+
+	tests := []struct {
+		handler http.HandlerFunc
+		target  string
+	}{
+		{h.GetTrendingMovies, "/api/trending/movies"},
+		{h.GetTrendingTV, "/api/trending/tv?time_window=day"},
+		{h.Search, "/api/search?query=thrones"},
+		{h.SearchByType, "/api/search/movie?query=godfather&year=1974"},
+		{h.SearchByType, "/api/search/tv?query=bad&first_air_date_year=2008"},
+		{h.SearchByType, "/api/search/person?query=pitt"},
+		{h.SearchByType, "/api/search/company?query=films"},
+		{h.SearchByType, "/api/search/keyword?query=nolly"},
+		{h.SearchByType, "/api/search/collection?query=godfather"},
+		{h.GetDetails, "/api/details/movie/550"},
+		{h.GetDetails, "/api/details/movie/550?include=credits,videos,images"},
+		{h.GetDetails, "/api/details/tv/1399"},
+		{h.GetCredits, "/api/credits/movie/550"},
+		{h.GetCredits, "/api/credits/tv/1399"},
+		{h.GetGenres, "/api/genres/movie"},
+		{h.GetGenres, "/api/genres/tv"},
+		{h.GetByGenre, "/api/discover/movie/878"},
+		{h.GetByGenre, "/api/discover/tv/popular?page=1"},
+		{h.GetDiscover, "/api/discover/movie?with_genres=28|53&vote_average.gte=8&sort_by=vote_average.desc"},
+		{h.GetDiscover, "/api/discover/tv?from=2010-01-01&with_original_language=EN&with_watch_providers=8&region=KE"},
+		{h.GetDiscover, "/api/discover/movie?primary_release_date.gte=1990-01-01&primary_release_date.lte=1999-12-31&with_runtime.gte=90"},
+		{h.GetDiscover, "/api/discover/tv?first_air_date.gte=2010-01-01&runtime.lte=60&language=fr"},
+		{h.GetVideos, "/api/videos/movie/550"},
+		{h.GetImages, "/api/images/tv/1399"},
+		{h.GetWatchProviders, "/api/watch/providers/movie/550"},
+		{h.GetRecommendations, "/api/recommendations/tv/1399"},
+		{h.GetSimilar, "/api/similar/movie/550?page=2"},
+		{h.GetSimilar, "/api/similar/tv/1399"},
+		{h.GetReviews, "/api/reviews/movie/550"},
+		{h.GetReviews, "/api/reviews/tv/1399?page=1"},
+		{h.GetKeywords, "/api/keywords/movie/550"},
+		{h.GetKeywords, "/api/keywords/tv/1399"},
+		{h.GetByKeyword, "/api/keyword/movie/1721"},
+		{h.GetByKeyword, "/api/keyword/tv/6091?page=2"},
+		{h.GetCertification, "/api/certification/movie/550?country=ng"},
+		{h.GetCertification, "/api/certification/tv/1399?region=US"},
+		{h.GetCertifications, "/api/certifications/movie"},
+		{h.GetCertifications, "/api/certifications/tv"},
+		{h.GetDiscover, "/api/discover/movie?certification.lte=PG-13&certification_country=US"},
+		{h.GetExternalIDs, "/api/external_ids/movie/550"},
+		{h.GetExternalIDs, "/api/external_ids/tv/1399"},
+		{h.GetExternalIDs, "/api/external_ids/person/287"},
+		{h.GetFind, "/api/find/imdb/tt0137523"},
+		{h.GetFind, "/api/find/tvdb/121361"},
+		{h.GetFind, "/api/find/wikidata/Q190050"},
+		{h.GetFind, "/api/find/imdb/nm0000093"},
+		{h.GetTVSeason, "/api/tv/1399/season/1"},
+		{h.GetTVEpisode, "/api/tv/1399/season/1/episode/1"},
+		{h.GetPerson, "/api/person/287"},
+		{h.GetPersonCredits, "/api/person/287/credits"},
+		{h.GetPersonImages, "/api/person/287/images"},
+		{h.GetCollection, "/api/collection/230"},
+		{h.GetCollectionImages, "/api/collection/230/images"},
+		{h.GetList, "/api/lists/movie/now_playing?region=KE"},
+		{h.GetList, "/api/lists/movie/upcoming"},
+		{h.GetList, "/api/lists/movie/top_rated?page=2"},
+		{h.GetList, "/api/lists/tv/airing_today"},
+		{h.GetList, "/api/lists/tv/on_the_air"},
+		{h.GetList, "/api/lists/tv/top_rated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := serve(tt.handler, tt.target, time.Second)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+			}
+			if got := rec.Header().Get("Cache-Control"); got == "" || got == "no-store" {
+				t.Fatalf("Cache-Control = %q, want a public max-age", got)
+			}
+			var body map[string]any
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body) == 0 {
+				t.Fatal("empty response body")
+			}
+		})
+	}
+}
+
+func TestHandlersCheckCertificationFilter(t *testing.T) {
+	h, srv := newTestHandler(t, "token")
+
+	for _, target := range []string{
+		"/api/discover/movie?certification.lte=PG13&certification_country=US",
+		// PG-13 is a US rating, not a British one.
+		"/api/discover/movie?certification.lte=PG-13&region=GB",
+		"/api/discover/tv?certification.lte=12A&certification_country=GB",
+		"/api/discover/movie?certification.lte=PG&certification_country=FR",
+	} {
+		rec := serve(h.GetDiscover, target, time.Second)
+		assertError(t, rec, http.StatusBadRequest, "bad_request")
+	}
+	if n := srv.Hits("/discover/movie") + srv.Hits("/discover/tv"); n != 0 {
+		t.Fatalf("made %d discover requests for unknown certifications", n)
+	}
+
+	rec := serve(h.GetDiscover, "/api/discover/movie?certification.lte=pg-13&region=US", time.Second)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	reqs := srv.Requests()
+	q := reqs[len(reqs)-1].Query()
+	if q.Get("certification.lte") != "PG-13" || q.Get("certification_country") != "US" || q.Has("include_adult") {
+		t.Fatalf("discover query = %v", q)
+	}
+}
+
+func TestHandlersCacheControlFollowsTTL(t *testing.T) {
+	h, _ := newTestHandler(t, "token")
+
+	tests := []struct {
+		handler http.HandlerFunc
+		target  string
+		want    string
+	}{
+		{h.GetGenres, "/api/genres/movie", "public, max-age=86400"},
+		{h.GetTrendingMovies, "/api/trending/movies", "public, max-age=600"},
+		{h.GetKeywords, "/api/keywords/movie/550", "public, max-age=86400"},
+		{h.GetDetails, "/api/details/movie/550", "public, max-age=3600"},
+		{h.GetCertification, "/api/certification/movie/550?country=US", "public, max-age=86400"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := serve(tt.handler, tt.target, time.Second)
+			if got := rec.Header().Get("Cache-Control"); got != tt.want {
+				t.Fatalf("Cache-Control = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlersRejectBadRequests(t *testing.T) {
+	h, srv := newTestHandler(t, "token")
+
+	tests := []struct {
+		handler http.HandlerFunc
+		target  string
+	}{
+		{h.GetTrendingMovies, "/api/trending/movies?time_window=month"},
+		{h.Search, "/api/search"},
+		{h.Search, "/api/search?query=dark&page=0"},
+		{h.SearchByType, "/api/search/movie"},
+		{h.SearchByType, "/api/search/movie?query=godfather&year=72"},
+		{h.SearchByType, "/api/search/tv?query=bad&first_air_date_year=recent"},
+		{h.SearchByType, "/api/search/network?query=hbo"},
+		{h.SearchByType, "/api/search/movie?query=godfather&first_air_date_year=1972"},
+		{h.SearchByType, "/api/search/tv?query=bad&year=2008"},
+		{h.SearchByType, "/api/search/person?query=pitt&year=1963"},
+		{h.SearchByType, "/api/search/collection?query=godfather&include_adult=true"},
+		{h.GetDetails, "/api/details/person/550"},
+		{h.GetDetails, "/api/details/movie/550?include=credits,reviews"},
+		{h.GetDetails, "/api/details/movie/550?include=credits,"},
+		{h.GetByGenre, "/api/discover/movie/drama"},
+		{h.GetDiscover, "/api/discover/person"},
+		{h.GetDiscover, "/api/discover/movie?with_genres=28,53|12"},
+		{h.GetDiscover, "/api/discover/movie?with_genres=action"},
+		{h.GetDiscover, "/api/discover/movie?from=2020-13-01"},
+		{h.GetDiscover, "/api/discover/movie?vote_average.gte=11"},
+		{h.GetDiscover, "/api/discover/tv?sort_by=revenue.desc"},
+		{h.GetDiscover, "/api/discover/tv?with_origin_country=Nigeria"},
+		{h.GetDiscover, "/api/discover/movie?with_watch_monetization_types=stream"},
+		{h.GetDiscover, "/api/discover/movie?page=0"},
+		{h.GetDiscover, "/api/discover/movie?with_cast=287"},
+		{h.GetDiscover, "/api/discover/tv?primary_release_date.gte=2010-01-01"},
+		{h.GetDiscover, "/api/discover/movie?from=2010-01-01&primary_release_date.gte=2011-01-01"},
+		{h.GetDiscover, "/api/discover/movie?with_watch_providers=8&language=en"},
+		{h.GetVideos, "/api/videos/movie"},
+		{h.GetSimilar, "/api/similar/movie/fight-club"},
+		{h.GetSimilar, "/api/similar/person/287"},
+		{h.GetReviews, "/api/reviews/movie/550?page=-1"},
+		{h.GetKeywords, "/api/keywords/tv"},
+		{h.GetByKeyword, "/api/keyword/movie/fight"},
+		{h.GetByKeyword, "/api/keyword/person/1721"},
+		{h.GetCertification, "/api/certification/movie/550?country=Nigeria"},
+		{h.GetCertification, "/api/certification/person/287?country=US"},
+		{h.GetCertification, "/api/certification/movie/550?language=en"},
+		{h.GetCertifications, "/api/certifications/person"},
+		{h.GetDiscover, "/api/discover/movie?certification.lte=PG&language=en"},
+		{h.GetExternalIDs, "/api/external_ids/collection/230"},
+		{h.GetExternalIDs, "/api/external_ids/movie/tt0137523"},
+		{h.GetFind, "/api/find/letterboxd/fight-club"},
+		{h.GetFind, "/api/find/imdb/0137523"},
+		{h.GetFind, "/api/find/tvdb/tt0944947"},
+		{h.GetFind, "/api/find/wikidata/190050"},
+		{h.GetFind, "/api/find/twitter/"},
+		{h.GetTVSeason, "/api/tv/1399/season/first"},
+		{h.GetTVSeason, "/api/tv/1399/season/-1"},
+		{h.GetTVEpisode, "/api/tv/1399/season/1/episode/0"},
+		{h.GetTVEpisode, "/api/tv/1399/season/1/chapter/1"},
+		{h.GetPerson, "/api/person/brad-pitt"},
+		{h.GetPersonCredits, "/api/person/0/credits"},
+		{h.GetCollection, "/api/collection/godfather"},
+		{h.GetCollectionImages, "/api/collection/230/posters"},
+		{h.GetList, "/api/lists/tv/upcoming"},
+		{h.GetList, "/api/lists/movie/popular"},
+		{h.GetList, "/api/lists/movie/top_rated?page=first"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := serve(tt.handler, tt.target, time.Second)
+			assertError(t, rec, http.StatusBadRequest, "bad_request")
+		})
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("made %d upstream requests for invalid input", n)
+	}
+}
+
+func TestHandlersMapUpstreamErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		target string
+		setup  func(*tmdbtest.Server)
+		status int
+		code   string
+	}{
+		{"not found", "token", "/api/details/movie/1", nil, http.StatusNotFound, "not_found"},
+		{"unauthorized", "", "/api/details/movie/550", nil, http.StatusUnauthorized, "unauthorized"},
+		{"server error", "token", "/api/details/movie/550", func(s *tmdbtest.Server) {
+			s.FailNext(1, http.StatusInternalServerError)
+		}, http.StatusBadGateway, "upstream_error"},
+		{"timeout", "token", "/api/details/movie/550", func(s *tmdbtest.Server) {
+			s.SetLatency(time.Minute)
+		}, http.StatusGatewayTimeout, "upstream_timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, srv := newTestHandler(t, tt.token)
+			if tt.setup != nil {
+				tt.setup(srv)
+			}
+			rec := serve(h.GetDetails, tt.target, 50*time.Millisecond)
+			assertError(t, rec, tt.status, tt.code)
+		})
+	}
+}
+
+func TestHandlersFindNothing(t *testing.T) {
+	h, srv := newTestHandler(t, "token")
+
+	rec := serve(h.GetFind, "/api/find/imdb/tt0000001", time.Second)
+	assertError(t, rec, http.StatusNotFound, "not_found")
+	if n := srv.Hits("/find/tt0000001"); n != 1 {
+		t.Fatalf("made %d upstream requests, want 1", n)
+	}
+}
+
+func TestHandlersPassOnRateLimits(t *testing.T) {
+	h, srv := newTestHandler(t, "token")
+	srv.RateLimitNext(1, 30*time.Second)
+
+	rec := serve(h.GetDetails, "/api/details/movie/550", time.Second)
+	assertError(t, rec, http.StatusTooManyRequests, "rate_limited")
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Fatalf("Retry-After = %q, want 30", got)
+	}
+}
+
+func TestHandlersFlagStaleData(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	h, srv := newTestHandler(t, "token",
+		tmdb.WithClock(clock),
+		tmdb.WithTTLPolicy(tmdb.TTLPolicy{Default: time.Minute}),
+		tmdb.WithStalePolicy(tmdb.StalePolicy{IfError: time.Hour}),
+	)
+
+	rec := serve(h.GetCredits, "/api/credits/movie/550", time.Second)
+	if rec.Code != http.StatusOK || rec.Header().Get(StaleHeader) != "" {
+		t.Fatalf("first response: status %d, stale %q", rec.Code, rec.Header().Get(StaleHeader))
+	}
+
+	clock.Advance(10 * time.Minute)
+	srv.FailNext(1, http.StatusServiceUnavailable)
+
+	rec = serve(h.GetCredits, "/api/credits/movie/550", time.Second)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want stale 200: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get(StaleHeader); got != "true" {
+		t.Fatalf("%s = %q, want true", StaleHeader, got)
+	}
+}
+
+func TestHandlersRevalidateStaleData(t *testing.T) {
+	const callers = 10
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	h, srv := newTestHandler(t, "token",
+		tmdb.WithClock(clock),
+		tmdb.WithTTLPolicy(tmdb.TTLPolicy{Default: time.Minute}),
+		tmdb.WithStalePolicy(tmdb.StalePolicy{WhileRevalidate: time.Hour}),
+	)
+	title := func(rec *httptest.ResponseRecorder) string {
+		var body struct{ Title string }
+		json.NewDecoder(rec.Body).Decode(&body)
+		return body.Title
+	}
+
+	rec := serve(h.GetDetails, "/api/details/movie/550", time.Second)
+	if rec.Code != http.StatusOK || title(rec) != "Fight Club" {
+		t.Fatalf("first response: status %d", rec.Code)
+	}
+
+	// TMDB now has a newer copy, and is slow to send it.
+	srv.SetFixture("/movie/550", []byte(`{"id":550,"title":"Fight Club (Remastered)"}`))
+	srv.SetLatency(500 * time.Millisecond)
+	clock.Advance(10 * time.Minute)
+
+	var wg sync.WaitGroup
+	recs := make([]*httptest.ResponseRecorder, callers)
+	start := time.Now()
+	for i := range recs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recs[i] = serve(h.GetDetails, "/api/details/movie/550", time.Second)
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Fatalf("stale responses took %v, want them before the refresh lands", elapsed)
+	}
+	for _, rec := range recs {
+		if rec.Code != http.StatusOK || rec.Header().Get(StaleHeader) != "true" {
+			t.Fatalf("status %d, %s %q; want a stale 200", rec.Code, StaleHeader, rec.Header().Get(StaleHeader))
+		}
+		if got := title(rec); got != "Fight Club" {
+			t.Fatalf("title = %q, want the cached one", got)
+		}
+	}
+
+	// Wait out the single background refresh.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		rec = serve(h.GetDetails, "/api/details/movie/550", time.Second)
+		if rec.Header().Get(StaleHeader) == "" || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if rec.Header().Get(StaleHeader) != "" {
+		t.Fatal("entry was never refreshed")
+	}
+	if got := title(rec); got != "Fight Club (Remastered)" {
+		t.Fatalf("title = %q, want the refreshed one", got)
+	}
+	if n := srv.Hits("/movie/550"); n != 2 {
+		t.Fatalf("upstream hits = %d, want the first fetch and one refresh", n)
+	}
+}
+
+func assertError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, status, rec.Body)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Fatalf("Cache-Control = %q, want no-store", got)
+	}
+	var body errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != code || body.Status != status {
+		t.Fatalf("body = %+v, want code %q and status %d", body, code, status)
+	}
+}
+
+func TestRequestLocale(t *testing.T) {
+	tests := []struct {
+		target         string
+		acceptLanguage string
+		want           tmdb.Locale
+		wantErr        bool
+	}{
+		{target: "/", want: tmdb.Locale{}},
+		{target: "/?language=pt-br", want: tmdb.Locale{Language: "pt-BR", Region: "BR"}},
+		{target: "/?language=fr&region=sn", want: tmdb.Locale{Language: "fr", Region: "SN"}},
+		{target: "/?language=ar", acceptLanguage: "sw-KE", want: tmdb.Locale{Language: "ar"}},
+		{target: "/", acceptLanguage: "sw-KE;q=0.8, fr;q=0.9, *;q=0.1", want: tmdb.Locale{Language: "fr"}},
+		{target: "/", acceptLanguage: "pt-PT", want: tmdb.Locale{Language: "pt-PT"}},
+		{target: "/?region=pt", acceptLanguage: "pt-PT", want: tmdb.Locale{Language: "pt-PT", Region: "PT"}},
+		{target: "/", acceptLanguage: "zh-Hant-TW, garbage", want: tmdb.Locale{}},
+		{target: "/?language=english", wantErr: true},
+		{target: "/?region=Kenya", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target+" "+tt.acceptLanguage, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			got, err := requestLocale(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("locale = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlersLocalizeUpstreamRequests(t *testing.T) {
+	h, srv := newTestHandler(t, "token")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/discover/movie/popular", nil)
+	req.Header.Set("Accept-Language", "fr-SN,fr;q=0.9")
+	TrackUpstream(http.HandlerFunc(h.GetByGenre)).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Values("Vary"); len(got) == 0 || got[len(got)-1] != "Accept-Language" {
+		t.Fatalf("Vary = %v, want Accept-Language", got)
+	}
+	q := srv.Requests()[0].Query()
+	if q.Get("language") != "fr-SN" || q.Has("region") {
+		t.Fatalf("upstream language %q region %q, want fr-SN and no region", q.Get("language"), q.Get("region"))
+	}
+
+	rec = serve(h.GetDetails, "/api/details/movie/550?language=klingon", time.Second)
+	assertError(t, rec, http.StatusBadRequest, "bad_request")
+}
+
+func TestHandlersIgnoreAcceptLanguageRegion(t *testing.T) {
+	h, srv := newTestHandler(t, "token")
+	get := func(handler http.HandlerFunc, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept-Language", "en-GB,en;q=0.9")
+		TrackUpstream(handler).ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A browser's en-GB must not narrow lists to GB releases...
+	rec := get(h.GetList, "/api/lists/movie/now_playing")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if q := srv.Requests()[0].Query(); q.Get("language") != "en-GB" || q.Has("region") {
+		t.Fatalf("upstream query = %v, want en-GB and no region", q)
+	}
+
+	// ...nor pick a certification country for the caller.
+	rec = get(h.GetCertification, "/api/certification/movie/550")
+	assertError(t, rec, http.StatusBadRequest, "bad_request")
+
+	// The language parameter still implies its region.
+	rec = get(h.GetList, "/api/lists/movie/now_playing?language=en-KE")
+	if q := srv.Requests()[1].Query(); rec.Code != http.StatusOK || q.Get("region") != "KE" {
+		t.Fatalf("status %d, upstream query = %v, want region KE", rec.Code, q)
+	}
+}
+
+func TestDiscoverOptionsAcceptTMDBNames(t *testing.T) {
+	q, _ := url.ParseQuery("first_air_date.gte=2010-01-01&first_air_date.lte=2012-12-31&with_runtime.gte=30&runtime.lte=60")
+	opts, problem := discoverOptions(q, "tv")
+	if problem != "" {
+		t.Fatal(problem)
+	}
+	if opts.From.Year() != 2010 || opts.To.Year() != 2012 || opts.MinRuntime != 30 || opts.MaxRuntime != 60 {
+		t.Fatalf("opts = %+v", opts)
+	}
+}
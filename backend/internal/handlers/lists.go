@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"afroflix/pkg/tmdb"
+)
+
+// GetList serves /api/lists/{type}/{list}: now_playing, upcoming and
+// top_rated for movies, airing_today, on_the_air and top_rated for TV.
+func (h *Handler) GetList(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/lists/"), "/")
+	if len(parts) != 2 {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+
+	page, ok := pageParam(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid page parameter")
+		return
+	}
+
+	var (
+		results *tmdb.ListResponse
+		err     error
+	)
+
+	switch parts[0] + "/" + parts[1] {
+	case "movie/now_playing":
+		results, err = h.tmdbClient.GetNowPlayingMovies(r.Context(), page, loc)
+	case "movie/upcoming":
+		results, err = h.tmdbClient.GetUpcomingMovies(r.Context(), page, loc)
+	case "movie/top_rated":
+		results, err = h.tmdbClient.GetTopRatedMovies(r.Context(), page, loc)
+	case "tv/airing_today":
+		results, err = h.tmdbClient.GetAiringTodayTV(r.Context(), page, loc)
+	case "tv/on_the_air":
+		results, err = h.tmdbClient.GetOnTheAirTV(r.Context(), page, loc)
+	case "tv/top_rated":
+		results, err = h.tmdbClient.GetTopRatedTV(r.Context(), page, loc)
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid list")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch list")
+		return
+	}
+
+	h.sendResult(w, r, results)
+}
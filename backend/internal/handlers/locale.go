@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"afroflix/pkg/tmdb"
+)
+
+var (
+	languagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+	regionPattern   = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// requestLocale reads the language and region a caller wants from the
+// language and region query parameters, falling back to the preferred
+// Accept-Language tag. A region left unset is taken from the language
+// parameter, so ?language=pt-BR also means region BR, but never from the
+// header: a browser sending en-US has not asked for US-only results.
+// Anything not given is left empty for the client's defaults.
+func requestLocale(r *http.Request) (tmdb.Locale, error) {
+	var (
+		loc            tmdb.Locale
+		languageRegion string
+	)
+	query := r.URL.Query()
+
+	if v := query.Get("language"); v != "" {
+		lang, ok := normalizeLanguage(v)
+		if !ok {
+			return tmdb.Locale{}, errors.New("invalid language parameter")
+		}
+		loc.Language = lang
+		_, languageRegion, _ = strings.Cut(lang, "-")
+	} else {
+		// An unusable header is ignored rather than rejected; browsers send
+		// all sorts of things.
+		loc.Language = acceptLanguage(r.Header.Get("Accept-Language"))
+	}
+
+	if v := query.Get("region"); v != "" {
+		region := strings.ToUpper(v)
+		if !regionPattern.MatchString(region) {
+			return tmdb.Locale{}, errors.New("invalid region parameter")
+		}
+		loc.Region = region
+	} else {
+		loc.Region = languageRegion
+	}
+	return loc, nil
+}
+
+// normalizeLanguage canonicalizes the case of a language tag such as
+// "pt-br" to TMDB's "pt-BR".
+func normalizeLanguage(tag string) (string, bool) {
+	lang, region, hasRegion := strings.Cut(tag, "-")
+	tag = strings.ToLower(lang)
+	if hasRegion {
+		tag += "-" + strings.ToUpper(region)
+	}
+	return tag, languagePattern.MatchString(tag)
+}
+
+// acceptLanguage returns the most preferred usable tag in an
+// Accept-Language header, or "" if there is none.
+func acceptLanguage(header string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag, ok := normalizeLanguage(strings.TrimSpace(tag))
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].tag
+}
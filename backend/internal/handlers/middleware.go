@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"afroflix/pkg/tmdb"
+)
+
+// Timeout bounds every request's context by d, so the deadline reaches any
+// upstream TMDB call made while serving it.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// TrackUpstream attaches a tmdb.ResponseInfo to every request, which
+// sendResult uses to describe the upstream data behind the response.
+func TrackUpstream(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, _ := tmdb.WithResponseInfo(r.Context())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
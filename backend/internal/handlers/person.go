@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+func (h *Handler) GetPerson(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(r, "/api/person/", "")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	person, err := h.tmdbClient.GetPerson(r.Context(), id, loc)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch person")
+		return
+	}
+
+	h.sendResult(w, r, person)
+}
+
+func (h *Handler) GetPersonCredits(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(r, "/api/person/", "/credits")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	credits, err := h.tmdbClient.GetPersonCredits(r.Context(), id, loc)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch person credits")
+		return
+	}
+
+	h.sendResult(w, r, credits)
+}
+
+func (h *Handler) GetPersonImages(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r, "/api/person/", "/images")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	images, err := h.tmdbClient.GetPersonImages(r.Context(), id)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch person images")
+		return
+	}
+
+	h.sendResult(w, r, images)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"cmp"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"afroflix/pkg/tmdb"
+)
+
+// pageParam returns the page query parameter, 1 if it is absent.
+func pageParam(r *http.Request) (int, bool) {
+	p := queryParser{q: r.URL.Query()}
+	page := p.page()
+	return page, p.bad == ""
+}
+
+// queryParser reads typed query parameters, remembering the first one that
+// does not parse. Absent parameters read as zero values.
+type queryParser struct {
+	q    url.Values
+	bad  string
+	read map[string]bool
+}
+
+// get returns the raw value of key, noting that it was asked for.
+func (p *queryParser) get(key string) string {
+	if p.read == nil {
+		p.read = make(map[string]bool)
+	}
+	p.read[key] = true
+	return p.q.Get(key)
+}
+
+// either returns whichever of keys the caller sent, so a parameter can go
+// by more than one name. Sending two of them is malformed.
+func (p *queryParser) either(keys ...string) string {
+	found := ""
+	for _, key := range keys {
+		if p.q.Has(key) {
+			if found != "" {
+				p.fail(key)
+			}
+			found = key
+		}
+	}
+	return cmp.Or(found, keys[0])
+}
+
+// unknown returns the first parameter, in name order, that was never asked
+// for and is not one of except, or "" if there is none.
+func (p *queryParser) unknown(except ...string) string {
+	for _, key := range slices.Sorted(maps.Keys(p.q)) {
+		if !p.read[key] && !slices.Contains(except, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+func (p *queryParser) fail(key string) {
+	if p.bad == "" {
+		p.bad = key
+	}
+}
+
+func (p *queryParser) int(key string) int {
+	v := p.get(key)
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		p.fail(key)
+	}
+	return n
+}
+
+func (p *queryParser) float(key string) float64 {
+	v := p.get(key)
+	if v == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		p.fail(key)
+	}
+	return f
+}
+
+// page reads the page number, 1 if it is absent.
+func (p *queryParser) page() int {
+	v := p.get("page")
+	if v == "" {
+		return 1
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		p.fail("page")
+	}
+	return n
+}
+
+// year reads a four-digit year, 0 if it is absent.
+func (p *queryParser) year(key string) int {
+	v := p.get(key)
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || len(v) != 4 || n < 1800 {
+		p.fail(key)
+	}
+	return n
+}
+
+// date parses a YYYY-MM-DD date.
+func (p *queryParser) date(key string) time.Time {
+	v := p.get(key)
+	if v == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		p.fail(key)
+	}
+	return t
+}
+
+// code reads a two-letter language or country code.
+func (p *queryParser) code(key string) string {
+	v := p.get(key)
+	if v == "" {
+		return ""
+	}
+	if len(v) != 2 || !isLetter(v[0]) || !isLetter(v[1]) {
+		p.fail(key)
+		return ""
+	}
+	return v
+}
+
+// list splits a parameter on "," or "|", which TMDB uses for all-of and
+// any-of. A parameter mixing both is malformed.
+func (p *queryParser) list(key string) []string {
+	v := p.get(key)
+	if v == "" {
+		return nil
+	}
+	if strings.Contains(v, ",") && strings.Contains(v, "|") {
+		p.fail(key)
+		return nil
+	}
+	return strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == '|' })
+}
+
+// ids reads a list of TMDB IDs such as "28,12" (all of) or "28|12" (any of).
+func (p *queryParser) ids(key string) tmdb.IDFilter {
+	f := tmdb.IDFilter{Any: strings.Contains(p.get(key), "|")}
+	for _, s := range p.list(key) {
+		id, err := strconv.Atoi(s)
+		if err != nil || id < 1 {
+			p.fail(key)
+			return tmdb.IDFilter{}
+		}
+		f.IDs = append(f.IDs, id)
+	}
+	return f
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// GetSimilar serves /api/similar/{type}/{id}, titles like a movie or show
+// by genre and keywords.
+func (h *Handler) GetSimilar(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	mediaType, id, ok := titlePath(r, "/api/similar/")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+	page, ok := pageParam(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid page parameter")
+		return
+	}
+
+	var (
+		similar interface{}
+		err     error
+	)
+
+	switch mediaType {
+	case "movie":
+		similar, err = h.tmdbClient.GetSimilarMovies(r.Context(), id, page, loc)
+	case "tv":
+		similar, err = h.tmdbClient.GetSimilarTV(r.Context(), id, page, loc)
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch similar titles")
+		return
+	}
+
+	h.sendResult(w, r, similar)
+}
+
+// GetReviews serves /api/reviews/{type}/{id}, a page of a movie's or
+// show's user reviews.
+func (h *Handler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	mediaType, id, ok := titlePath(r, "/api/reviews/")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+	page, ok := pageParam(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid page parameter")
+		return
+	}
+
+	var (
+		reviews interface{}
+		err     error
+	)
+
+	switch mediaType {
+	case "movie":
+		reviews, err = h.tmdbClient.GetMovieReviews(r.Context(), id, page)
+	case "tv":
+		reviews, err = h.tmdbClient.GetTVReviews(r.Context(), id, page)
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch reviews")
+		return
+	}
+
+	h.sendResult(w, r, reviews)
+}
+
+// GetKeywords serves /api/keywords/{type}/{id}, a movie's or show's
+// keyword tags.
+func (h *Handler) GetKeywords(w http.ResponseWriter, r *http.Request) {
+	mediaType, id, ok := titlePath(r, "/api/keywords/")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+
+	var (
+		keywords interface{}
+		err      error
+	)
+
+	switch mediaType {
+	case "movie":
+		keywords, err = h.tmdbClient.GetMovieKeywords(r.Context(), id)
+	case "tv":
+		keywords, err = h.tmdbClient.GetTVKeywords(r.Context(), id)
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch keywords")
+		return
+	}
+
+	h.sendResult(w, r, keywords)
+}
+
+// GetByKeyword serves /api/keyword/{type}/{id}, the most popular titles
+// tagged with a keyword.
+func (h *Handler) GetByKeyword(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	mediaType, id, ok := titlePath(r, "/api/keyword/")
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+	keywordID, _ := strconv.Atoi(id)
+	page, ok := pageParam(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid page parameter")
+		return
+	}
+
+	var (
+		results interface{}
+		err     error
+	)
+
+	switch mediaType {
+	case "movie":
+		results, err = h.tmdbClient.GetMoviesByKeyword(r.Context(), keywordID, page, loc)
+	case "tv":
+		results, err = h.tmdbClient.GetTVByKeyword(r.Context(), keywordID, page, loc)
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid media type")
+		return
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch content")
+		return
+	}
+
+	h.sendResult(w, r, results)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SearchByType serves /api/search/{type} for movie, tv, person, company,
+// keyword and collection. Movies can be narrowed by year and shows by
+// first_air_date_year; any other parameter is rejected.
+func (h *Handler) SearchByType(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	p := queryParser{q: r.URL.Query()}
+	query := p.get("query")
+	if query == "" {
+		h.sendError(w, http.StatusBadRequest, "query parameter is required")
+		return
+	}
+	page := p.page()
+
+	mediaType := strings.TrimPrefix(r.URL.Path, "/api/search/")
+	var year int
+	switch mediaType {
+	case "movie":
+		year = p.year("year")
+	case "tv":
+		year = p.year("first_air_date_year")
+	case "person", "company", "keyword", "collection":
+	default:
+		h.sendError(w, http.StatusBadRequest, "invalid search type")
+		return
+	}
+	if p.bad != "" {
+		h.sendError(w, http.StatusBadRequest, "invalid "+p.bad+" parameter")
+		return
+	}
+	if key := p.unknown("language", "region"); key != "" {
+		h.sendError(w, http.StatusBadRequest, "unsupported "+key+" parameter")
+		return
+	}
+
+	var (
+		results interface{}
+		err     error
+	)
+
+	switch mediaType {
+	case "movie":
+		results, err = h.tmdbClient.SearchMovies(r.Context(), query, year, page, loc)
+	case "tv":
+		results, err = h.tmdbClient.SearchTV(r.Context(), query, year, page, loc)
+	case "person":
+		results, err = h.tmdbClient.SearchPeople(r.Context(), query, page, loc)
+	case "company":
+		results, err = h.tmdbClient.SearchCompanies(r.Context(), query, page)
+	case "keyword":
+		results, err = h.tmdbClient.SearchKeywords(r.Context(), query, page)
+	case "collection":
+		results, err = h.tmdbClient.SearchCollections(r.Context(), query, page, loc)
+	}
+
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to search")
+		return
+	}
+
+	h.sendResult(w, r, results)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// tvPath splits /api/tv/{id}/season/{n}[/episode/{e}] into its numbers,
+// with episode -1 when the path names none.
+func tvPath(r *http.Request) (id string, season, episode int, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/tv/"), "/")
+	if (len(parts) != 3 && len(parts) != 5) || parts[1] != "season" {
+		return "", 0, 0, false
+	}
+
+	showID, err := strconv.Atoi(parts[0])
+	if err != nil || showID < 1 {
+		return "", 0, 0, false
+	}
+	// Season 0 holds specials.
+	season, err = strconv.Atoi(parts[2])
+	if err != nil || season < 0 {
+		return "", 0, 0, false
+	}
+	episode = -1
+	if len(parts) == 5 {
+		if parts[3] != "episode" {
+			return "", 0, 0, false
+		}
+		episode, err = strconv.Atoi(parts[4])
+		if err != nil || episode < 1 {
+			return "", 0, 0, false
+		}
+	}
+	return parts[0], season, episode, true
+}
+
+func (h *Handler) GetTVSeason(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	id, season, _, ok := tvPath(r)
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+
+	details, err := h.tmdbClient.GetTVSeason(r.Context(), id, season, loc)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch season")
+		return
+	}
+
+	h.sendResult(w, r, details)
+}
+
+func (h *Handler) GetTVEpisode(w http.ResponseWriter, r *http.Request) {
+	loc, ok := h.locale(w, r)
+	if !ok {
+		return
+	}
+
+	id, season, episode, ok := tvPath(r)
+	if !ok || episode < 1 {
+		h.sendError(w, http.StatusBadRequest, "invalid path")
+		return
+	}
+
+	details, err := h.tmdbClient.GetTVEpisode(r.Context(), id, season, episode, loc)
+	if err != nil {
+		h.sendUpstreamError(w, err, "failed to fetch episode")
+		return
+	}
+
+	h.sendResult(w, r, details)
+}
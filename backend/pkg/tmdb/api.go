@@ -1,6 +1,7 @@
 package tmdb
 
 import (
+	"context"
 	"net/url"
 	"strconv"
 )
@@ -8,8 +9,8 @@ import (
 type TrendingResponse struct {
 	Page         int         `json:"page"`
 	Results      []MediaItem `json:"results"`
-	TotalPages   int        `json:"total_pages"`
-	TotalResults int        `json:"total_results"`
+	TotalPages   int         `json:"total_pages"`
+	TotalResults int         `json:"total_results"`
 }
 
 type MediaItem struct {
@@ -86,7 +87,7 @@ type WatchProvidersResponse struct {
 }
 
 type WatchProviderCountry struct {
-	Link     string           `json:"link"`
+	Link     string          `json:"link"`
 	Flatrate []WatchProvider `json:"flatrate"`
 	Rent     []WatchProvider `json:"rent"`
 	Buy      []WatchProvider `json:"buy"`
@@ -99,273 +100,264 @@ type WatchProvider struct {
 	ProviderName    string `json:"provider_name"`
 }
 
-func (c *Client) GetTrendingMovies(timeWindow string) (*TrendingResponse, error) {
+// forRegion narrows the response to a single region's providers, or
+// returns it unchanged if region is empty. TMDB always answers for every
+// region, so this is done after caching.
+func (r *WatchProvidersResponse) forRegion(region string) *WatchProvidersResponse {
+	if region == "" {
+		return r
+	}
+	filtered := &WatchProvidersResponse{Results: map[string]WatchProviderCountry{}}
+	if country, ok := r.Results[region]; ok {
+		filtered.Results[region] = country
+	}
+	return filtered
+}
+
+func (c *Client) GetTrendingMovies(ctx context.Context, timeWindow string, loc Locale) (*TrendingResponse, error) {
 	var response TrendingResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/trending/movie/"+timeWindow, params, &response)
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/trending/movie/"+timeWindow, params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetTrendingTV(timeWindow string) (*TrendingResponse, error) {
+func (c *Client) GetTrendingTV(ctx context.Context, timeWindow string, loc Locale) (*TrendingResponse, error) {
 	var response TrendingResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/trending/tv/"+timeWindow, params, &response)
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/trending/tv/"+timeWindow, params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) SearchMulti(query string, page int) (*TrendingResponse, error) {
+func (c *Client) SearchMulti(ctx context.Context, query string, page int, loc Locale) (*TrendingResponse, error) {
 	var response TrendingResponse
+	loc = c.locale(loc)
 	params := url.Values{}
 	params.Set("query", query)
 	params.Set("include_adult", "true")
-	params.Set("language", "en-US")
+	params.Set("language", loc.Language)
 	params.Set("page", strconv.Itoa(page))
-	
-	err := c.get("/search/multi", params, &response)
-	if err != nil {
-		return nil, err
-	}
-	return &response, nil
-}
 
-func (c *Client) GetMovieDetails(id string) (*MediaItem, error) {
-	var response MediaItem
-	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/movie/"+id, params, &response)
+	err := c.get(ctx, "/search/multi", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetTVDetails(id string) (*MediaItem, error) {
-	var response MediaItem
-	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/tv/"+id, params, &response)
-	if err != nil {
-		return nil, err
-	}
-	return &response, nil
-}
-
-func (c *Client) GetMovieCredits(id string) (*CreditsResponse, error) {
+func (c *Client) GetMovieCredits(ctx context.Context, id string, loc Locale) (*CreditsResponse, error) {
 	var response CreditsResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/movie/"+id+"/credits", params, &response)
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/movie/"+id+"/credits", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetTVCredits(id string) (*CreditsResponse, error) {
+func (c *Client) GetTVCredits(ctx context.Context, id string, loc Locale) (*CreditsResponse, error) {
 	var response CreditsResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/tv/"+id+"/credits", params, &response)
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/tv/"+id+"/credits", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetMovieGenres() (*GenreResponse, error) {
+func (c *Client) GetMovieGenres(ctx context.Context, loc Locale) (*GenreResponse, error) {
 	var response GenreResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/genre/movie/list", params, &response)
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/genre/movie/list", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetTVGenres() (*GenreResponse, error) {
+func (c *Client) GetTVGenres(ctx context.Context, loc Locale) (*GenreResponse, error) {
 	var response GenreResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/genre/tv/list", params, &response)
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/genre/tv/list", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetMoviesByGenre(genreID int, page int) (*TrendingResponse, error) {
-	var response TrendingResponse
-	params := url.Values{}
-	params.Set("include_adult", "true")
-	params.Set("include_video", "true")
-	params.Set("language", "en-US")
-	params.Set("page", strconv.Itoa(page))
-	params.Set("sort_by", "popularity.desc")
-	params.Set("with_genres", strconv.Itoa(genreID))
-	
-	err := c.get("/discover/movie", params, &response)
-	if err != nil {
-		return nil, err
-	}
-	return &response, nil
+func (c *Client) GetMoviesByGenre(ctx context.Context, genreID int, page int, loc Locale) (*TrendingResponse, error) {
+	return c.DiscoverMovies(ctx, DiscoverOptions{Genres: IDFilter{IDs: []int{genreID}}, Page: page}, loc)
 }
 
-func (c *Client) GetTVByGenre(genreID int, page int) (*TrendingResponse, error) {
-	var response TrendingResponse
-	params := url.Values{}
-	params.Set("include_adult", "true")
-	params.Set("include_null_first_air_dates", "false")
-	params.Set("language", "en-US")
-	params.Set("page", strconv.Itoa(page))
-	params.Set("sort_by", "popularity.desc")
-	params.Set("with_genres", strconv.Itoa(genreID))
-	
-	err := c.get("/discover/tv", params, &response)
-	if err != nil {
-		return nil, err
-	}
-	return &response, nil
+func (c *Client) GetTVByGenre(ctx context.Context, genreID int, page int, loc Locale) (*TrendingResponse, error) {
+	return c.DiscoverTV(ctx, DiscoverOptions{Genres: IDFilter{IDs: []int{genreID}}, Page: page}, loc)
+}
+
+func (c *Client) GetPopularMovies(ctx context.Context, page int, loc Locale) (*TrendingResponse, error) {
+	return c.DiscoverMovies(ctx, DiscoverOptions{Page: page}, loc)
 }
 
-func (c *Client) GetPopularMovies(page int) (*TrendingResponse, error) {
+func (c *Client) GetPopularTV(ctx context.Context, page int, loc Locale) (*TrendingResponse, error) {
+	return c.DiscoverTV(ctx, DiscoverOptions{Page: page}, loc)
+}
+
+func (c *Client) GetMovieRecommendations(ctx context.Context, id string, loc Locale) (*TrendingResponse, error) {
 	var response TrendingResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("include_adult", "true")
-	params.Set("include_video", "true")
-	params.Set("language", "en-US")
-	params.Set("page", strconv.Itoa(page))
-	params.Set("sort_by", "popularity.desc")
-	
-	err := c.get("/discover/movie", params, &response)
+	params.Set("language", loc.Language)
+	params.Set("page", "1")
+
+	err := c.get(ctx, "/movie/"+id+"/recommendations", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetPopularTV(page int) (*TrendingResponse, error) {
+func (c *Client) GetTVRecommendations(ctx context.Context, id string, loc Locale) (*TrendingResponse, error) {
 	var response TrendingResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("include_adult", "true")
-	params.Set("include_null_first_air_dates", "false")
-	params.Set("language", "en-US")
-	params.Set("page", strconv.Itoa(page))
-	params.Set("sort_by", "popularity.desc")
-	
-	err := c.get("/discover/tv", params, &response)
+	params.Set("language", loc.Language)
+	params.Set("page", "1")
+
+	err := c.get(ctx, "/tv/"+id+"/recommendations", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetMovieRecommendations(id string) (*TrendingResponse, error) {
+func (c *Client) GetSimilarMovies(ctx context.Context, id string, page int, loc Locale) (*TrendingResponse, error) {
 	var response TrendingResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	params.Set("page", "1")
-	
-	err := c.get("/movie/"+id+"/recommendations", params, &response)
+	params.Set("language", loc.Language)
+	params.Set("page", strconv.Itoa(page))
+
+	err := c.get(ctx, "/movie/"+id+"/similar", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetTVRecommendations(id string) (*TrendingResponse, error) {
+func (c *Client) GetSimilarTV(ctx context.Context, id string, page int, loc Locale) (*TrendingResponse, error) {
 	var response TrendingResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	params.Set("page", "1")
-	
-	err := c.get("/tv/"+id+"/recommendations", params, &response)
+	params.Set("language", loc.Language)
+	params.Set("page", strconv.Itoa(page))
+
+	err := c.get(ctx, "/tv/"+id+"/similar", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetMovieVideos(id string) (*VideoResponse, error) {
+func (c *Client) GetMovieVideos(ctx context.Context, id string, loc Locale) (*VideoResponse, error) {
 	var response VideoResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/movie/"+id+"/videos", params, &response)
+	params.Set("language", loc.Language)
+	// Fall back to English trailers when none exist in the language asked for.
+	params.Set("include_video_language", primaryLanguage(loc.Language)+",en")
+
+	err := c.get(ctx, "/movie/"+id+"/videos", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetTVVideos(id string) (*VideoResponse, error) {
+func (c *Client) GetTVVideos(ctx context.Context, id string, loc Locale) (*VideoResponse, error) {
 	var response VideoResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	params.Set("language", "en-US")
-	
-	err := c.get("/tv/"+id+"/videos", params, &response)
+	params.Set("language", loc.Language)
+	// Fall back to English trailers when none exist in the language asked for.
+	params.Set("include_video_language", primaryLanguage(loc.Language)+",en")
+
+	err := c.get(ctx, "/tv/"+id+"/videos", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetMovieImages(id string) (*ImagesResponse, error) {
+func (c *Client) GetMovieImages(ctx context.Context, id string, loc Locale) (*ImagesResponse, error) {
 	var response ImagesResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	
-	err := c.get("/movie/"+id+"/images", params, &response)
+	// Include textless images, which suit every language.
+	params.Set("include_image_language", primaryLanguage(loc.Language)+",null")
+
+	err := c.get(ctx, "/movie/"+id+"/images", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetTVImages(id string) (*ImagesResponse, error) {
+func (c *Client) GetTVImages(ctx context.Context, id string, loc Locale) (*ImagesResponse, error) {
 	var response ImagesResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	
-	err := c.get("/tv/"+id+"/images", params, &response)
+	// Include textless images, which suit every language.
+	params.Set("include_image_language", primaryLanguage(loc.Language)+",null")
+
+	err := c.get(ctx, "/tv/"+id+"/images", params, &response)
 	if err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (c *Client) GetMovieWatchProviders(id string) (*WatchProvidersResponse, error) {
+func (c *Client) GetMovieWatchProviders(ctx context.Context, id string, loc Locale) (*WatchProvidersResponse, error) {
 	var response WatchProvidersResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	
-	err := c.get("/movie/"+id+"/watch/providers", params, &response)
+
+	err := c.get(ctx, "/movie/"+id+"/watch/providers", params, &response)
 	if err != nil {
 		return nil, err
 	}
-	return &response, nil
+	return response.forRegion(loc.Region), nil
 }
 
-func (c *Client) GetTVWatchProviders(id string) (*WatchProvidersResponse, error) {
+func (c *Client) GetTVWatchProviders(ctx context.Context, id string, loc Locale) (*WatchProvidersResponse, error) {
 	var response WatchProvidersResponse
+	loc = c.locale(loc)
 	params := url.Values{}
-	
-	err := c.get("/tv/"+id+"/watch/providers", params, &response)
+
+	err := c.get(ctx, "/tv/"+id+"/watch/providers", params, &response)
 	if err != nil {
 		return nil, err
 	}
-	return &response, nil
-} 
\ No newline at end of file
+	return response.forRegion(loc.Region), nil
+}
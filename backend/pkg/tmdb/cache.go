@@ -0,0 +1,138 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// CacheBackend stores raw TMDB response bodies. Implementations must be
+// safe for concurrent use. A backend may drop an entry at any time after
+// it expires, and must not return it once it has.
+type CacheBackend interface {
+	// Get returns the entry for key, with ok false on a miss.
+	Get(ctx context.Context, key string) (entry CacheEntry, ok bool, err error)
+	Set(ctx context.Context, key string, entry CacheEntry) error
+	Delete(ctx context.Context, key string) error
+	Stats() CacheStats
+	Close() error
+}
+
+// CacheEntry is a response body together with when it stops being fresh
+// and, if it is kept for serving stale, when it must be dropped. Value is
+// shared with every reader and must not be modified.
+type CacheEntry struct {
+	Value      []byte
+	Expires    time.Time
+	StaleUntil time.Time
+}
+
+func (e CacheEntry) fresh(now time.Time) bool {
+	return now.Before(e.Expires)
+}
+
+// expired reports whether the entry is of no further use, fresh or stale.
+// Backends drop entries once they have expired.
+func (e CacheEntry) expired(now time.Time) bool {
+	return !now.Before(e.retainUntil())
+}
+
+func (e CacheEntry) retainUntil() time.Time {
+	if e.StaleUntil.After(e.Expires) {
+		return e.StaleUntil
+	}
+	return e.Expires
+}
+
+// cacheClock is the time a backend checks expiry against. It is the wall
+// clock until the client that owns the backend hands over its own, which
+// may happen while the backend's janitor is running.
+type cacheClock struct {
+	fn atomic.Pointer[func() time.Time]
+}
+
+func (c *cacheClock) now() time.Time {
+	if fn := c.fn.Load(); fn != nil {
+		return (*fn)()
+	}
+	return time.Now()
+}
+
+func (c *cacheClock) setNow(now func() time.Time) {
+	c.fn.Store(&now)
+}
+
+// CacheStats is a snapshot of a backend's size and counters. Backends
+// report what they can track cheaply and leave the rest zero.
+type CacheStats struct {
+	Entries int
+	Bytes   int64
+	// Hits and Misses count lookups; Evictions counts entries dropped to
+	// stay within limits, Expirations those dropped for age, and Errors
+	// failed backend operations.
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	Errors      uint64
+}
+
+// WithCache replaces the default in-memory cache. The client takes
+// ownership of the backend and closes it in Close.
+func WithCache(backend CacheBackend) Option {
+	return func(c *Client) {
+		c.cache = backend
+	}
+}
+
+// CacheStats reports the current state of the client's response cache.
+func (c *Client) CacheStats() CacheStats {
+	return c.cache.Stats()
+}
+
+// Backends that persist entries outside the process store them as a small
+// versioned header followed by the body. Entries in an older format are
+// treated as malformed and simply refetched.
+const (
+	entryFormat     = 2
+	entryHeaderSize = 17
+)
+
+var errBadEntry = errors.New("tmdb: malformed cache entry")
+
+func marshalEntry(e CacheEntry) []byte {
+	b := make([]byte, entryHeaderSize, entryHeaderSize+len(e.Value))
+	b[0] = entryFormat
+	binary.BigEndian.PutUint64(b[1:9], uint64(unixNano(e.Expires)))
+	binary.BigEndian.PutUint64(b[9:17], uint64(unixNano(e.StaleUntil)))
+	return append(b, e.Value...)
+}
+
+func unmarshalEntry(b []byte) (CacheEntry, error) {
+	if len(b) < entryHeaderSize || b[0] != entryFormat {
+		return CacheEntry{}, errBadEntry
+	}
+	return CacheEntry{
+		Value:      b[entryHeaderSize:],
+		Expires:    fromUnixNano(int64(binary.BigEndian.Uint64(b[1:9]))),
+		StaleUntil: fromUnixNano(int64(binary.BigEndian.Uint64(b[9:17]))),
+	}, nil
+}
+
+// unixNano and fromUnixNano round-trip the zero time, which UnixNano
+// cannot represent, as 0.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
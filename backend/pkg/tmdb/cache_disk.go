@@ -0,0 +1,177 @@
+package tmdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DiskCache is a CacheBackend that keeps one file per entry under a
+// directory, so a warm cache survives restarts. It has no size bound of
+// its own; expired files are removed on access and by a periodic sweep.
+type DiskCache struct {
+	dir string
+	cacheClock
+
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	expirations atomic.Uint64
+	errors      atomic.Uint64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDiskCache stores entries under dir, creating it if needed. Expired
+// files are swept every cleanupInterval, or only on access if it is zero.
+func NewDiskCache(dir string, cleanupInterval time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &DiskCache{
+		dir:  dir,
+		stop: make(chan struct{}),
+	}
+	if cleanupInterval > 0 {
+		go c.janitor(cleanupInterval)
+	}
+	return c, nil
+}
+
+// path maps a key to a file name. Keys contain slashes and query strings,
+// so they are hashed, and fanned out over subdirectories to keep any one
+// directory small.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, name[:2], name)
+}
+
+func (c *DiskCache) Get(ctx context.Context, key string) (CacheEntry, bool, error) {
+	path := c.path(key)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		c.misses.Add(1)
+		return CacheEntry{}, false, nil
+	}
+	if err != nil {
+		c.errors.Add(1)
+		return CacheEntry{}, false, err
+	}
+
+	entry, err := unmarshalEntry(b)
+	if err != nil {
+		// A torn or foreign file; drop it rather than failing forever.
+		os.Remove(path)
+		c.misses.Add(1)
+		return CacheEntry{}, false, nil
+	}
+	if entry.expired(c.now()) {
+		os.Remove(path)
+		c.expirations.Add(1)
+		c.misses.Add(1)
+		return CacheEntry{}, false, nil
+	}
+	c.hits.Add(1)
+	return entry, true, nil
+}
+
+func (c *DiskCache) Set(ctx context.Context, key string, entry CacheEntry) error {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		c.errors.Add(1)
+		return err
+	}
+
+	// Write to a temporary file and rename it into place, so readers never
+	// see a partial entry.
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		c.errors.Add(1)
+		return err
+	}
+	_, err = f.Write(marshalEntry(entry))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		c.errors.Add(1)
+		return err
+	}
+	return nil
+}
+
+func (c *DiskCache) Delete(ctx context.Context, key string) error {
+	err := os.Remove(c.path(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		c.errors.Add(1)
+		return err
+	}
+	return nil
+}
+
+func (c *DiskCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Expirations: c.expirations.Load(),
+		Errors:      c.errors.Load(),
+	}
+}
+
+func (c *DiskCache) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	return nil
+}
+
+// removeExpired walks the cache directory and deletes expired entries,
+// reading only each file's header.
+func (c *DiskCache) removeExpired() {
+	now := c.now()
+	header := make([]byte, entryHeaderSize)
+	filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		_, err = io.ReadFull(f, header)
+		f.Close()
+		if err != nil {
+			return nil
+		}
+		if entry, err := unmarshalEntry(header); err == nil && entry.expired(now) {
+			if os.Remove(path) == nil {
+				c.expirations.Add(1)
+			}
+		}
+		return nil
+	})
+}
+
+func (c *DiskCache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.removeExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package tmdb
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheMaxEntries      = 10000
+	defaultCacheMaxBytes        = 64 << 20
+	defaultCacheCleanupInterval = time.Minute
+)
+
+// MemoryCache is a bounded LRU CacheBackend held in process memory. A
+// background janitor drops expired entries so they do not hold memory
+// until they happen to be read again.
+type MemoryCache struct {
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+	bytes int64
+
+	maxEntries int
+	maxBytes   int64
+	cacheClock
+
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+	expirations uint64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type memoryItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// size approximates the memory held by the item.
+func (i *memoryItem) size() int64 {
+	return int64(len(i.key) + len(i.entry.Value))
+}
+
+// NewMemoryCache returns a cache bounded by entry count and by total size
+// in bytes; zero leaves that dimension unbounded. Expired entries are
+// swept every cleanupInterval, or only on access if it is zero.
+func NewMemoryCache(maxEntries int, maxBytes int64, cleanupInterval time.Duration) *MemoryCache {
+	c := &MemoryCache{
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		stop:       make(chan struct{}),
+	}
+	if cleanupInterval > 0 {
+		go c.janitor(cleanupInterval)
+	}
+	return c
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) (CacheEntry, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return CacheEntry{}, false, nil
+	}
+	item := el.Value.(*memoryItem)
+	if item.entry.expired(c.now()) {
+		c.removeElement(el)
+		c.expirations++
+		c.misses++
+		return CacheEntry{}, false, nil
+	}
+	c.ll.MoveToFront(el)
+	c.hits++
+	return item.entry, true, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := &memoryItem{key: key, entry: entry}
+
+	// A single response larger than the whole budget is not worth caching,
+	// and an older, smaller response for the key is now out of date.
+	if c.maxBytes > 0 && item.size() > c.maxBytes {
+		if el, ok := c.items[key]; ok {
+			c.removeElement(el)
+		}
+		return nil
+	}
+
+	if el, ok := c.items[key]; ok {
+		c.bytes -= el.Value.(*memoryItem).size()
+		el.Value = item
+		c.ll.MoveToFront(el)
+	} else {
+		c.items[key] = c.ll.PushFront(item)
+	}
+	c.bytes += item.size()
+
+	for c.overLimit() {
+		c.removeElement(c.ll.Back())
+		c.evictions++
+	}
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+	return nil
+}
+
+func (c *MemoryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Entries:     c.ll.Len(),
+		Bytes:       c.bytes,
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Evictions:   c.evictions,
+		Expirations: c.expirations,
+	}
+}
+
+func (c *MemoryCache) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	return nil
+}
+
+func (c *MemoryCache) overLimit() bool {
+	if c.ll.Len() == 0 {
+		return false
+	}
+	return (c.maxEntries > 0 && c.ll.Len() > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+func (c *MemoryCache) removeElement(el *list.Element) {
+	item := c.ll.Remove(el).(*memoryItem)
+	delete(c.items, item.key)
+	c.bytes -= item.size()
+}
+
+// removeExpired drops every expired entry. Entries are not ordered by age,
+// so this walks the whole list.
+func (c *MemoryCache) removeExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for el := c.ll.Back(); el != nil; {
+		prev := el.Prev()
+		if el.Value.(*memoryItem).entry.expired(now) {
+			c.removeElement(el)
+			c.expirations++
+		}
+		el = prev
+	}
+}
+
+func (c *MemoryCache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.removeExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
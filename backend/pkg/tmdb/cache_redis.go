@@ -0,0 +1,107 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultRedisKeyPrefix = "tamasha:tmdb:"
+
+// RedisCacheOptions configures a RedisCache.
+type RedisCacheOptions struct {
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	// KeyPrefix namespaces cache keys; it defaults to "tamasha:tmdb:".
+	KeyPrefix string
+}
+
+// RedisCache is a CacheBackend that talks to any server speaking the Redis
+// protocol, so several API replicas can share one warm cache. Expiry is
+// delegated to the server.
+type RedisCache struct {
+	client *redis.Client
+	prefix string
+	cacheClock
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+}
+
+func NewRedisCache(opts RedisCacheOptions) *RedisCache {
+	prefix := opts.KeyPrefix
+	if prefix == "" {
+		prefix = defaultRedisKeyPrefix
+	}
+	return &RedisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:     opts.Addr,
+			Username: opts.Username,
+			Password: opts.Password,
+			DB:       opts.DB,
+		}),
+		prefix: prefix,
+	}
+}
+
+// Ping checks that the server is reachable.
+func (c *RedisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) (CacheEntry, bool, error) {
+	b, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		c.misses.Add(1)
+		return CacheEntry{}, false, nil
+	}
+	if err != nil {
+		c.errors.Add(1)
+		return CacheEntry{}, false, err
+	}
+
+	entry, err := unmarshalEntry(b)
+	if err != nil || entry.expired(c.now()) {
+		c.misses.Add(1)
+		return CacheEntry{}, false, nil
+	}
+	c.hits.Add(1)
+	return entry, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, entry CacheEntry) error {
+	ttl := entry.retainUntil().Sub(c.now())
+	if ttl <= 0 {
+		return nil
+	}
+	if err := c.client.Set(ctx, c.prefix+key, marshalEntry(entry), ttl).Err(); err != nil {
+		c.errors.Add(1)
+		return err
+	}
+	return nil
+}
+
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, c.prefix+key).Err(); err != nil {
+		c.errors.Add(1)
+		return err
+	}
+	return nil
+}
+
+func (c *RedisCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Errors: c.errors.Load(),
+	}
+}
+
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,233 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// testBackends builds each kind of backend, empty.
+var testBackends = map[string]func(t *testing.T) CacheBackend{
+	"memory": func(t *testing.T) CacheBackend {
+		return NewMemoryCache(0, 0, 0)
+	},
+	"disk": func(t *testing.T) CacheBackend {
+		c, err := NewDiskCache(t.TempDir(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	},
+	"redis": func(t *testing.T) CacheBackend {
+		mr := miniredis.RunT(t)
+		return NewRedisCache(RedisCacheOptions{Addr: mr.Addr()})
+	},
+}
+
+func TestCacheBackends(t *testing.T) {
+	for name, newBackend := range testBackends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newBackend(t)
+			defer c.Close()
+
+			if _, ok, err := c.Get(ctx, "/movie/550"); ok || err != nil {
+				t.Fatalf("Get on empty cache = %v, %v; want miss", ok, err)
+			}
+
+			want := []byte(`{"id":550}`)
+			if err := c.Set(ctx, "/movie/550", CacheEntry{Value: want, Expires: time.Now().Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+			got, ok, err := c.Get(ctx, "/movie/550")
+			if err != nil || !ok {
+				t.Fatalf("Get after Set = %v, %v; want hit", ok, err)
+			}
+			if string(got.Value) != string(want) {
+				t.Fatalf("Get = %s, want %s", got.Value, want)
+			}
+
+			if err := c.Set(ctx, "/movie/551", CacheEntry{Value: want, Expires: time.Now().Add(-time.Second)}); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok, _ := c.Get(ctx, "/movie/551"); ok {
+				t.Fatal("Get returned an expired entry")
+			}
+
+			if err := c.Delete(ctx, "/movie/550"); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok, _ := c.Get(ctx, "/movie/550"); ok {
+				t.Fatal("Get returned a deleted entry")
+			}
+
+			stats := c.Stats()
+			if stats.Hits != 1 || stats.Misses != 3 {
+				t.Fatalf("stats = %+v, want 1 hit and 3 misses", stats)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(2, 0, 0)
+	defer c.Close()
+
+	entry := CacheEntry{Value: []byte(`{}`), Expires: time.Now().Add(time.Hour)}
+	c.Set(ctx, "a", entry)
+	c.Set(ctx, "b", entry)
+	c.Get(ctx, "a") // a is now more recent than b
+	c.Set(ctx, "c", entry)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Errorf("entry %q was evicted", key)
+		}
+	}
+	if stats := c.Stats(); stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("stats = %+v, want 2 entries and 1 eviction", stats)
+	}
+}
+
+func TestMemoryCacheByteBudget(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(0, 100, 0)
+	defer c.Close()
+
+	expires := time.Now().Add(time.Hour)
+	c.Set(ctx, "a", CacheEntry{Value: make([]byte, 60), Expires: expires})
+	c.Set(ctx, "b", CacheEntry{Value: make([]byte, 50), Expires: expires})
+	if stats := c.Stats(); stats.Entries != 1 || stats.Bytes > 100 {
+		t.Fatalf("stats = %+v, want one entry within 100 bytes", stats)
+	}
+
+	// Larger than the whole budget: not cached, nothing else disturbed.
+	c.Set(ctx, "huge", CacheEntry{Value: make([]byte, 200), Expires: expires})
+	if _, ok, _ := c.Get(ctx, "huge"); ok {
+		t.Error("oversized entry was cached")
+	}
+	if _, ok, _ := c.Get(ctx, "b"); !ok {
+		t.Error("oversized entry evicted an existing one")
+	}
+
+	// An oversized update must not leave the old value behind.
+	c.Set(ctx, "b", CacheEntry{Value: make([]byte, 200), Expires: expires})
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Error("oversized update kept the previous entry")
+	}
+	if stats := c.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("stats = %+v, want an empty cache", stats)
+	}
+}
+
+func TestDiskCacheSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	c, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set(ctx, "/genre/movie/list", CacheEntry{Value: []byte(`{"genres":[]}`), Expires: time.Now().Add(time.Hour)})
+	c.Close()
+
+	c, err = NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, ok, _ := c.Get(ctx, "/genre/movie/list"); !ok {
+		t.Fatal("entry did not survive reopening the cache")
+	}
+}
+
+func TestRedisCacheIsSharedAndExpiresServerSide(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+
+	a := NewRedisCache(RedisCacheOptions{Addr: mr.Addr()})
+	defer a.Close()
+	b := NewRedisCache(RedisCacheOptions{Addr: mr.Addr()})
+	defer b.Close()
+
+	a.Set(ctx, "/trending/movie/week", CacheEntry{Value: []byte(`{}`), Expires: time.Now().Add(time.Minute)})
+	if _, ok, _ := b.Get(ctx, "/trending/movie/week"); !ok {
+		t.Fatal("entry written by one replica is not visible to another")
+	}
+	if !mr.Exists(defaultRedisKeyPrefix + "/trending/movie/week") {
+		t.Fatal("key was not namespaced with the default prefix")
+	}
+
+	mr.FastForward(2 * time.Minute)
+	if mr.Exists(defaultRedisKeyPrefix + "/trending/movie/week") {
+		t.Fatal("server did not expire the key")
+	}
+}
+
+func TestClientUsesConfiguredCache(t *testing.T) {
+	mr := miniredis.RunT(t)
+	srvHits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srvHits++
+		w.Write([]byte(`{"genres":[{"id":28,"name":"Action"}]}`))
+	}))
+	defer srv.Close()
+
+	for range 2 {
+		// A fresh client each time, as if on another replica.
+		c := NewClient("token", WithBaseURL(srv.URL), WithCache(NewRedisCache(RedisCacheOptions{Addr: mr.Addr()})))
+		resp, err := c.GetMovieGenres(context.Background(), Locale{})
+		c.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Genres) != 1 || resp.Genres[0].Name != "Action" {
+			t.Fatalf("unexpected genres %+v", resp.Genres)
+		}
+	}
+	if srvHits != 1 {
+		t.Fatalf("upstream hits = %d, want 1", srvHits)
+	}
+}
+
+func TestCacheBackendsUseClientClock(t *testing.T) {
+	for name, newBackend := range testBackends {
+		t.Run(name, func(t *testing.T) {
+			hits := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				w.Write([]byte(`{"genres":[]}`))
+			}))
+			defer srv.Close()
+
+			// The fake clock is years behind the wall clock, so a backend
+			// checking expiry against time.Now would never hit.
+			clock := newFakeClock()
+			c := NewClient("token", WithBaseURL(srv.URL), WithCache(newBackend(t)), WithClock(clock),
+				WithCacheTTL(time.Hour), WithStalePolicy(StalePolicy{}))
+			defer c.Close()
+
+			ctx := context.Background()
+			for _, step := range []struct {
+				advance time.Duration
+				hits    int
+			}{{0, 1}, {59 * time.Minute, 1}, {2 * time.Minute, 2}} {
+				clock.Advance(step.advance)
+				if _, err := c.GetMovieGenres(ctx, Locale{}); err != nil {
+					t.Fatal(err)
+				}
+				if hits != step.hits {
+					t.Fatalf("after %v, upstream hits = %d, want %d", step.advance, hits, step.hits)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package tmdb
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Release types, in TMDB's order.
+const (
+	ReleasePremiere = iota + 1
+	ReleaseTheatricalLimited
+	ReleaseTheatrical
+	ReleaseDigital
+	ReleasePhysical
+	ReleaseTV
+)
+
+type ReleaseDatesResponse struct {
+	ID      int                   `json:"id"`
+	Results []CountryReleaseDates `json:"results"`
+}
+
+type CountryReleaseDates struct {
+	ISO3166_1    string        `json:"iso_3166_1"`
+	ReleaseDates []ReleaseDate `json:"release_dates"`
+}
+
+type ReleaseDate struct {
+	Certification string   `json:"certification"`
+	Descriptors   []string `json:"descriptors"`
+	ISO639_1      string   `json:"iso_639_1"`
+	Note          string   `json:"note"`
+	ReleaseDate   string   `json:"release_date"`
+	Type          int      `json:"type"`
+}
+
+type ContentRatingsResponse struct {
+	ID      int             `json:"id"`
+	Results []ContentRating `json:"results"`
+}
+
+type ContentRating struct {
+	ISO3166_1   string   `json:"iso_3166_1"`
+	Rating      string   `json:"rating"`
+	Descriptors []string `json:"descriptors"`
+}
+
+// CertificationsResponse lists each country's certifications, keyed by ISO
+// 3166-1 code.
+type CertificationsResponse struct {
+	Certifications map[string][]CertificationMeaning `json:"certifications"`
+}
+
+// CertificationMeaning explains a certification. Order ranks it within its
+// country, from least to most restricted.
+type CertificationMeaning struct {
+	Certification string `json:"certification"`
+	Meaning       string `json:"meaning"`
+	Order         int    `json:"order"`
+}
+
+// Certification is a title's age rating in one country, the same for movies
+// and shows. Certification is empty when the title is not rated there.
+type Certification struct {
+	ID            int      `json:"id"`
+	MediaType     string   `json:"media_type"`
+	Country       string   `json:"country"`
+	Certification string   `json:"certification"`
+	Meaning       string   `json:"meaning,omitempty"`
+	Order         int      `json:"order,omitempty"`
+	Descriptors   []string `json:"descriptors"`
+	// ReleaseDate is the date of the release the certification comes
+	// from, for movies only.
+	ReleaseDate string `json:"release_date,omitempty"`
+}
+
+// GetMovieReleaseDates returns a movie's releases in each country, with
+// their certifications. TMDB does not localize them.
+func (c *Client) GetMovieReleaseDates(ctx context.Context, id string) (*ReleaseDatesResponse, error) {
+	var response ReleaseDatesResponse
+	params := url.Values{}
+
+	err := c.get(ctx, "/movie/"+id+"/release_dates", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetTVContentRatings returns a show's rating in each country. Like
+// release dates, they are not localized.
+func (c *Client) GetTVContentRatings(ctx context.Context, id string) (*ContentRatingsResponse, error) {
+	var response ContentRatingsResponse
+	params := url.Values{}
+
+	err := c.get(ctx, "/tv/"+id+"/content_ratings", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetMovieCertifications returns the movie certifications of every country
+// TMDB knows. They are not localized.
+func (c *Client) GetMovieCertifications(ctx context.Context) (*CertificationsResponse, error) {
+	return c.getCertifications(ctx, "/certification/movie/list")
+}
+
+func (c *Client) GetTVCertifications(ctx context.Context) (*CertificationsResponse, error) {
+	return c.getCertifications(ctx, "/certification/tv/list")
+}
+
+func (c *Client) getCertifications(ctx context.Context, endpoint string) (*CertificationsResponse, error) {
+	var response CertificationsResponse
+	params := url.Values{}
+
+	err := c.get(ctx, endpoint, params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetMovieCertification returns a movie's certification in country. It
+// comes from the theatrical release if that is rated, otherwise from the
+// first rated release.
+func (c *Client) GetMovieCertification(ctx context.Context, id, country string) (*Certification, error) {
+	dates, err := c.GetMovieReleaseDates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := c.GetMovieCertifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Certification{ID: dates.ID, MediaType: "movie", Country: country, Descriptors: []string{}}
+	for _, r := range dates.Results {
+		if r.ISO3166_1 != country {
+			continue
+		}
+		rated := slices.DeleteFunc(slices.Clone(r.ReleaseDates), func(d ReleaseDate) bool {
+			return d.Certification == ""
+		})
+		if len(rated) == 0 {
+			break
+		}
+		release := rated[0]
+		if i := slices.IndexFunc(rated, func(d ReleaseDate) bool { return d.Type == ReleaseTheatrical }); i >= 0 {
+			release = rated[i]
+		}
+		result.Certification = release.Certification
+		result.ReleaseDate = release.ReleaseDate
+		if release.Descriptors != nil {
+			result.Descriptors = release.Descriptors
+		}
+		break
+	}
+	result.explain(certs)
+	return result, nil
+}
+
+// GetTVCertification returns a show's content rating in country.
+func (c *Client) GetTVCertification(ctx context.Context, id, country string) (*Certification, error) {
+	ratings, err := c.GetTVContentRatings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := c.GetTVCertifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Certification{ID: ratings.ID, MediaType: "tv", Country: country, Descriptors: []string{}}
+	for _, r := range ratings.Results {
+		if r.ISO3166_1 == country {
+			result.Certification = r.Rating
+			if r.Descriptors != nil {
+				result.Descriptors = r.Descriptors
+			}
+			break
+		}
+	}
+	result.explain(certs)
+	return result, nil
+}
+
+// Lookup finds certification in country's list, ignoring case, e.g. "pg-13"
+// in "US". It reports false if the country does not use it.
+func (r *CertificationsResponse) Lookup(country, certification string) (CertificationMeaning, bool) {
+	for _, m := range r.Certifications[country] {
+		if strings.EqualFold(m.Certification, certification) {
+			return m, true
+		}
+	}
+	return CertificationMeaning{}, false
+}
+
+// explain fills in the meaning and order of the certification from the
+// country's reference list.
+func (cert *Certification) explain(certs *CertificationsResponse) {
+	for _, m := range certs.Certifications[cert.Country] {
+		if m.Certification == cert.Certification {
+			cert.Meaning = m.Meaning
+			cert.Order = m.Order
+			return
+		}
+	}
+}
@@ -1,66 +1,182 @@
 package tmdb
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-	"sync"
+	"strings"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 const (
-	baseURL      = "https://api.themoviedb.org/3"
-	cacheDuration = 5 * time.Minute
+	defaultBaseURL  = "https://api.themoviedb.org/3"
+	defaultTimeout  = 10 * time.Second
+	defaultCacheTTL = 5 * time.Minute
+	defaultLanguage = "en-US"
 )
 
 type Client struct {
 	httpClient *http.Client
+	baseURL    string
 	token      string
-	cache      *Cache
+	userAgent  string
+	language   string
+	region     string
+	clock      Clock
+	cache      CacheBackend
+	ttl        TTLPolicy
+	stale      StalePolicy
+	retry      RetryPolicy
+	limiter    *rate.Limiter
+	flights    flightGroup
+}
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithBaseURL points the client at a different TMDB API root.
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(u, "/")
+	}
 }
 
-type Cache struct {
-	sync.RWMutex
-	items map[string]*CacheItem
+// WithTimeout sets the overall timeout for a single upstream request.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
 }
 
-type CacheItem struct {
-	Data      interface{}
-	Timestamp time.Time
+// WithCacheTTL caches every endpoint for d, discarding all per-endpoint
+// rules, including those of DefaultTTLPolicy; a non-positive d disables
+// caching. To change only the lifetime of endpoints no rule matches, set
+// Default on DefaultTTLPolicy() and pass it to WithTTLPolicy instead.
+func WithCacheTTL(d time.Duration) Option {
+	return func(c *Client) {
+		c.ttl = TTLPolicy{Default: d}
+	}
 }
 
-func NewClient(token string) *Client {
-	return &Client{
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		token: token,
-		cache: &Cache{
-			items: make(map[string]*CacheItem),
+			Timeout: defaultTimeout,
 		},
+		baseURL:  defaultBaseURL,
+		token:    token,
+		language: defaultLanguage,
+		clock:    systemClock{},
+		retry:    DefaultRetryPolicy(),
+		ttl:      DefaultTTLPolicy(),
+		stale:    DefaultStalePolicy(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.cache == nil {
+		c.cache = NewMemoryCache(defaultCacheMaxEntries, defaultCacheMaxBytes, defaultCacheCleanupInterval)
+	}
+	// Backends check expiry against the client's clock, so a fake one
+	// governs the whole cache.
+	if cache, ok := c.cache.(interface{ setNow(func() time.Time) }); ok {
+		cache.setNow(c.clock.Now)
+	}
+	return c
+}
+
+// Close stops the client's background work. The client must not be used
+// afterwards.
+func (c *Client) Close() error {
+	return c.cache.Close()
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values, v interface{}) error {
+	return c.getTTL(ctx, endpoint, params, v, nil)
+}
+
+// ttlFunc picks how long to cache a response body, given the lifetime the
+// TTL policy sets for its endpoint.
+type ttlFunc func(body []byte, ttl time.Duration) time.Duration
+
+// cacheTTL is how long body from endpoint is cached: the policy's TTL,
+// adjusted by adjust if it is not nil.
+func (c *Client) cacheTTL(endpoint string, body []byte, adjust ttlFunc) time.Duration {
+	ttl := c.ttl.TTL(endpoint)
+	if adjust != nil {
+		ttl = adjust(body, ttl)
 	}
+	return ttl
 }
 
-func (c *Client) get(endpoint string, params url.Values, v interface{}) error {
+// getTTL is get for endpoints whose responses are not all worth caching
+// for the same time; adjust picks the TTL for each one.
+func (c *Client) getTTL(ctx context.Context, endpoint string, params url.Values, v interface{}, adjust ttlFunc) error {
 	cacheKey := endpoint + params.Encode()
+	info := ResponseInfoFrom(ctx)
 
-	// Check cache
-	if data := c.cache.get(cacheKey); data != nil {
-		if cached, ok := data.(map[string]interface{}); ok {
-			b, err := json.Marshal(cached)
-			if err == nil {
-				return json.Unmarshal(b, v)
-			}
+	// Check cache. A failing backend is treated as a miss; it shows up in
+	// CacheStats rather than failing the request.
+	entry, cached, _ := c.cache.Get(ctx, cacheKey)
+	if cached {
+		now := c.clock.Now()
+		switch {
+		case entry.fresh(now):
+			info.recordMaxAge(entry.Expires.Sub(now))
+			return decode(endpoint, entry.Value, v)
+		case c.stale.canRevalidate(entry, now):
+			c.revalidate(endpoint, params, cacheKey, adjust)
+			info.recordStale()
+			return decode(endpoint, entry.Value, v)
 		}
 	}
 
-	// Build URL
-	u, err := url.Parse(baseURL + endpoint)
+	// Fetch from upstream, sharing the request with any identical ones
+	// already in flight
+	body, _, err := c.flights.do(ctx, cacheKey, func(ctx context.Context) ([]byte, error) {
+		return c.fetch(ctx, endpoint, params, cacheKey, adjust)
+	})
 	if err != nil {
+		if err == ctx.Err() {
+			// We gave up waiting; the shared fetch may carry on for others
+			err = &NetworkError{Endpoint: endpoint, Err: err}
+		}
+		if cached && c.stale.canServeOnError(entry, c.clock.Now(), err) {
+			info.recordStale()
+			return decode(endpoint, entry.Value, v)
+		}
 		return err
 	}
 
+	info.recordMaxAge(c.cacheTTL(endpoint, body, adjust))
+	return decode(endpoint, body, v)
+}
+
+// decode unmarshals a response body into v. Bodies are shared between
+// callers via the cache and in-flight requests, so they must never be
+// modified.
+func decode(endpoint string, body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{Endpoint: endpoint, Err: err}
+	}
+	return nil
+}
+
+func (c *Client) fetch(ctx context.Context, endpoint string, params url.Values, cacheKey string, adjust ttlFunc) ([]byte, error) {
+	// Build URL
+	u, err := url.Parse(c.baseURL + endpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	// Add query parameters
 	q := u.Query()
 	for k, vs := range params {
@@ -71,63 +187,85 @@ func (c *Client) get(endpoint string, params url.Values, v interface{}) error {
 	u.RawQuery = q.Encode()
 
 	// Create request
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Add headers
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-
-	// Make request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	// Make request, retrying transient failures
+	body, err := c.do(ctx, endpoint, req)
+	if err != nil {
+		return nil, err
 	}
 
-	// Decode response
-	var data map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
+	// Never cache a body that no caller could decode
+	if !json.Valid(body) {
+		return nil, &DecodeError{Endpoint: endpoint, Err: errors.New("invalid JSON in response body")}
 	}
 
-	// Cache response
-	c.cache.set(cacheKey, data)
-
-	// Decode into target struct
-	b, err := json.Marshal(data)
-	if err != nil {
-		return err
+	// Cache response before the flight ends, so later callers hit the cache
+	if ttl := c.cacheTTL(endpoint, body, adjust); ttl > 0 {
+		expires := c.clock.Now().Add(ttl)
+		c.cache.Set(ctx, cacheKey, CacheEntry{
+			Value:      body,
+			Expires:    expires,
+			StaleUntil: expires.Add(c.stale.retention()),
+		})
 	}
-
-	return json.Unmarshal(b, v)
+	return body, nil
 }
 
-func (c *Cache) get(key string) interface{} {
-	c.RLock()
-	defer c.RUnlock()
+func (c *Client) do(ctx context.Context, endpoint string, req *http.Request) ([]byte, error) {
+	info := ResponseInfoFrom(ctx)
+	for attempt := 1; ; attempt++ {
+		if err := c.waitForToken(ctx, endpoint); err != nil {
+			return nil, err
+		}
+		info.recordAttempt(attempt > 1)
+		body, err := c.doOnce(endpoint, req)
+		if err == nil {
+			return body, nil
+		}
+
+		delay, ok := c.retry.backoff(ctx, req, attempt, err)
+		if !ok {
+			return nil, err
+		}
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(endpoint, attempt, err, delay)
+		}
 
-	if item, exists := c.items[key]; exists {
-		if time.Since(item.Timestamp) < cacheDuration {
-			return item.Data
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &NetworkError{Endpoint: endpoint, Err: ctx.Err()}
+		case <-timer.C:
 		}
-		delete(c.items, key)
 	}
-	return nil
 }
 
-func (c *Cache) set(key string, data interface{}) {
-	c.Lock()
-	defer c.Unlock()
+func (c *Client) doOnce(endpoint string, req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &NetworkError{Endpoint: endpoint, Err: err}
+	}
+	defer resp.Body.Close()
 
-	c.items[key] = &CacheItem{
-		Data:      data,
-		Timestamp: time.Now(),
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(endpoint, resp, c.clock.Now())
 	}
-} 
\ No newline at end of file
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &NetworkError{Endpoint: endpoint, Err: err}
+	}
+	return body, nil
+}
@@ -0,0 +1,94 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// largeCredits builds a credits payload about the size of a long-running
+// series with a big ensemble cast.
+func largeCredits(n int) []byte {
+	var resp CreditsResponse
+	for i := range n {
+		resp.Cast = append(resp.Cast, CastMember{
+			ID:          i,
+			Name:        fmt.Sprintf("Cast Member %d", i),
+			Character:   fmt.Sprintf("Character %d", i),
+			ProfilePath: fmt.Sprintf("/profile%d.jpg", i),
+		})
+		resp.Crew = append(resp.Crew, CrewMember{
+			ID:          n + i,
+			Name:        fmt.Sprintf("Crew Member %d", i),
+			Job:         "Director of Photography",
+			Department:  "Camera",
+			ProfilePath: fmt.Sprintf("/crew%d.jpg", i),
+		})
+	}
+	b, _ := json.Marshal(resp)
+	return b
+}
+
+func largeImages(n int) []byte {
+	var resp ImagesResponse
+	for i := range n {
+		img := Image{
+			FilePath:    fmt.Sprintf("/image%d.jpg", i),
+			AspectRatio: 1.778,
+			Height:      2160,
+			Width:       3840,
+			VoteAverage: 5.3,
+			VoteCount:   i,
+		}
+		resp.Backdrops = append(resp.Backdrops, img)
+		resp.Posters = append(resp.Posters, img)
+	}
+	b, _ := json.Marshal(resp)
+	return b
+}
+
+func benchmarkGet(b *testing.B, payload []byte, cached bool, get func(*Client) error) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	opts := []Option{WithBaseURL(srv.URL)}
+	if !cached {
+		// Every entry is expired on arrival, so each call goes upstream.
+		opts = append(opts, WithCacheTTL(0))
+	}
+	c := NewClient("token", opts...)
+	defer c.Close()
+
+	// Warm the cache so the cached variants measure hits only.
+	if err := get(c); err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(payload)))
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := get(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func getCredits(c *Client) error {
+	_, err := c.GetTVCredits(context.Background(), "1399", Locale{})
+	return err
+}
+
+func getImages(c *Client) error {
+	_, err := c.GetMovieImages(context.Background(), "550", Locale{})
+	return err
+}
+
+func BenchmarkCachedCredits(b *testing.B)   { benchmarkGet(b, largeCredits(2000), true, getCredits) }
+func BenchmarkUncachedCredits(b *testing.B) { benchmarkGet(b, largeCredits(2000), false, getCredits) }
+func BenchmarkCachedImages(b *testing.B)    { benchmarkGet(b, largeImages(1000), true, getImages) }
+func BenchmarkUncachedImages(b *testing.B)  { benchmarkGet(b, largeImages(1000), false, getImages) }
@@ -0,0 +1,955 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"afroflix/pkg/tmdb/tmdbtest"
+)
+
+// fakeClock is a Clock that only moves when told to.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestClient returns a client talking to a fresh fake TMDB, with retries
+// that back off quickly enough for tests.
+func newTestClient(t *testing.T, opts ...Option) (*Client, *tmdbtest.Server) {
+	t.Helper()
+	srv := tmdbtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	}, opts...)
+	c := NewClient("token", opts...)
+	t.Cleanup(func() { c.Close() })
+	return c, srv
+}
+
+func TestClientEndpoints(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() (int, error)
+	}{
+		{"GetTrendingMovies", func() (int, error) {
+			r, err := c.GetTrendingMovies(ctx, "week", Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTrendingTV", func() (int, error) {
+			r, err := c.GetTrendingTV(ctx, "day", Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"SearchMulti", func() (int, error) {
+			r, err := c.SearchMulti(ctx, "fight", 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"SearchMovies", func() (int, error) {
+			r, err := c.SearchMovies(ctx, "godfather", 0, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"SearchTV", func() (int, error) {
+			r, err := c.SearchTV(ctx, "thrones", 0, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"SearchPeople", func() (int, error) {
+			r, err := c.SearchPeople(ctx, "pitt", 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"SearchCompanies", func() (int, error) {
+			r, err := c.SearchCompanies(ctx, "paramount", 1)
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"SearchKeywords", func() (int, error) {
+			r, err := c.SearchKeywords(ctx, "nollywood", 1)
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"SearchCollections", func() (int, error) {
+			r, err := c.SearchCollections(ctx, "godfather", 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetMovieDetails", func() (int, error) {
+			r, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{})
+			return lenOr(r, err, func() int { return r.ID })
+		}},
+		{"GetTVDetails", func() (int, error) {
+			r, err := c.GetTVDetails(ctx, tmdbtest.TVID, Locale{})
+			return lenOr(r, err, func() int { return r.ID })
+		}},
+		{"GetMovieCredits", func() (int, error) {
+			r, err := c.GetMovieCredits(ctx, tmdbtest.MovieID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Cast) })
+		}},
+		{"GetTVCredits", func() (int, error) {
+			r, err := c.GetTVCredits(ctx, tmdbtest.TVID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Cast) })
+		}},
+		{"GetMovieGenres", func() (int, error) {
+			r, err := c.GetMovieGenres(ctx, Locale{})
+			return lenOr(r, err, func() int { return len(r.Genres) })
+		}},
+		{"GetTVGenres", func() (int, error) {
+			r, err := c.GetTVGenres(ctx, Locale{})
+			return lenOr(r, err, func() int { return len(r.Genres) })
+		}},
+		{"GetMoviesByGenre", func() (int, error) {
+			r, err := c.GetMoviesByGenre(ctx, 878, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVByGenre", func() (int, error) {
+			r, err := c.GetTVByGenre(ctx, 80, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetPopularMovies", func() (int, error) {
+			r, err := c.GetPopularMovies(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetPopularTV", func() (int, error) {
+			r, err := c.GetPopularTV(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetMovieRecommendations", func() (int, error) {
+			r, err := c.GetMovieRecommendations(ctx, tmdbtest.MovieID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVRecommendations", func() (int, error) {
+			r, err := c.GetTVRecommendations(ctx, tmdbtest.TVID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetSimilarMovies", func() (int, error) {
+			r, err := c.GetSimilarMovies(ctx, tmdbtest.MovieID, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetSimilarTV", func() (int, error) {
+			r, err := c.GetSimilarTV(ctx, tmdbtest.TVID, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetMovieReviews", func() (int, error) {
+			r, err := c.GetMovieReviews(ctx, tmdbtest.MovieID, 1)
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVReviews", func() (int, error) {
+			r, err := c.GetTVReviews(ctx, tmdbtest.TVID, 1)
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetMovieKeywords", func() (int, error) {
+			r, err := c.GetMovieKeywords(ctx, tmdbtest.MovieID)
+			return lenOr(r, err, func() int { return len(r.Keywords) })
+		}},
+		{"GetTVKeywords", func() (int, error) {
+			r, err := c.GetTVKeywords(ctx, tmdbtest.TVID)
+			return lenOr(r, err, func() int { return len(r.Keywords) })
+		}},
+		{"GetMoviesByKeyword", func() (int, error) {
+			r, err := c.GetMoviesByKeyword(ctx, 1721, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVByKeyword", func() (int, error) {
+			r, err := c.GetTVByKeyword(ctx, 6091, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetMovieReleaseDates", func() (int, error) {
+			r, err := c.GetMovieReleaseDates(ctx, tmdbtest.MovieID)
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVContentRatings", func() (int, error) {
+			r, err := c.GetTVContentRatings(ctx, tmdbtest.TVID)
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetMovieCertifications", func() (int, error) {
+			r, err := c.GetMovieCertifications(ctx)
+			return lenOr(r, err, func() int { return len(r.Certifications) })
+		}},
+		{"GetTVCertifications", func() (int, error) {
+			r, err := c.GetTVCertifications(ctx)
+			return lenOr(r, err, func() int { return len(r.Certifications) })
+		}},
+		{"GetMovieExternalIDs", func() (int, error) {
+			r, err := c.GetMovieExternalIDs(ctx, tmdbtest.MovieID)
+			return lenOr(r, err, func() int { return len(r.IMDbID) })
+		}},
+		{"GetTVExternalIDs", func() (int, error) {
+			r, err := c.GetTVExternalIDs(ctx, tmdbtest.TVID)
+			return lenOr(r, err, func() int { return r.TVDBID })
+		}},
+		{"GetPersonExternalIDs", func() (int, error) {
+			r, err := c.GetPersonExternalIDs(ctx, tmdbtest.PersonID)
+			return lenOr(r, err, func() int { return len(r.IMDbID) })
+		}},
+		{"GetMovieVideos", func() (int, error) {
+			r, err := c.GetMovieVideos(ctx, tmdbtest.MovieID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVVideos", func() (int, error) {
+			r, err := c.GetTVVideos(ctx, tmdbtest.TVID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetMovieImages", func() (int, error) {
+			r, err := c.GetMovieImages(ctx, tmdbtest.MovieID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Posters) })
+		}},
+		{"GetTVImages", func() (int, error) {
+			r, err := c.GetTVImages(ctx, tmdbtest.TVID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Posters) })
+		}},
+		{"GetMovieWatchProviders", func() (int, error) {
+			r, err := c.GetMovieWatchProviders(ctx, tmdbtest.MovieID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVWatchProviders", func() (int, error) {
+			r, err := c.GetTVWatchProviders(ctx, tmdbtest.TVID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTVSeason", func() (int, error) {
+			r, err := c.GetTVSeason(ctx, tmdbtest.TVID, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Episodes) })
+		}},
+		{"GetTVEpisode", func() (int, error) {
+			r, err := c.GetTVEpisode(ctx, tmdbtest.TVID, 1, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Images.Stills) })
+		}},
+		{"GetPerson", func() (int, error) {
+			r, err := c.GetPerson(ctx, tmdbtest.PersonID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Biography) })
+		}},
+		{"GetPersonCredits", func() (int, error) {
+			r, err := c.GetPersonCredits(ctx, tmdbtest.PersonID, Locale{})
+			return lenOr(r, err, func() int { return len(r.Cast) })
+		}},
+		{"GetPersonImages", func() (int, error) {
+			r, err := c.GetPersonImages(ctx, tmdbtest.PersonID)
+			return lenOr(r, err, func() int { return len(r.Profiles) })
+		}},
+		{"GetNowPlayingMovies", func() (int, error) {
+			r, err := c.GetNowPlayingMovies(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetUpcomingMovies", func() (int, error) {
+			r, err := c.GetUpcomingMovies(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTopRatedMovies", func() (int, error) {
+			r, err := c.GetTopRatedMovies(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetAiringTodayTV", func() (int, error) {
+			r, err := c.GetAiringTodayTV(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetOnTheAirTV", func() (int, error) {
+			r, err := c.GetOnTheAirTV(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+		{"GetTopRatedTV", func() (int, error) {
+			r, err := c.GetTopRatedTV(ctx, 1, Locale{})
+			return lenOr(r, err, func() int { return len(r.Results) })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := tt.call()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n == 0 {
+				t.Fatal("decoded an empty response")
+			}
+		})
+	}
+}
+
+// lenOr reports size(), or the error if the call failed.
+func lenOr[T any](resp *T, err error, size func() int) (int, error) {
+	if err != nil || resp == nil {
+		return 0, err
+	}
+	return size(), nil
+}
+
+func TestClientSendsParameters(t *testing.T) {
+	c, srv := newTestClient(t, WithLanguage("fr-FR"))
+
+	if _, err := c.GetMoviesByGenre(context.Background(), 18, 2, Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	q := reqs[0].Query()
+	for key, want := range map[string]string{"language": "fr-FR", "page": "2", "with_genres": "18"} {
+		if got := q.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestClientCachesResponses(t *testing.T) {
+	c, srv := newTestClient(t)
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := srv.Hits("/movie/550"); got != 1 {
+		t.Fatalf("upstream hits = %d, want 1", got)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		id     string
+		fail   int
+		status int
+		want   error
+		hits   int
+	}{
+		{name: "not found", token: "token", id: "1", want: ErrNotFound, hits: 1},
+		{name: "unauthorized", id: tmdbtest.MovieID, want: ErrUnauthorized, hits: 1},
+		{name: "server error", token: "token", id: tmdbtest.MovieID, fail: 3, status: http.StatusInternalServerError, want: ErrUnavailable, hits: 3},
+		{name: "rate limited", token: "token", id: tmdbtest.MovieID, fail: 3, status: http.StatusTooManyRequests, want: ErrRateLimited, hits: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := tmdbtest.NewServer()
+			defer srv.Close()
+			srv.FailNext(tt.fail, tt.status)
+
+			c := NewClient(tt.token,
+				WithBaseURL(srv.URL),
+				WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+			)
+			defer c.Close()
+
+			_, err := c.GetMovieDetails(context.Background(), tt.id, Locale{})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Message == "" {
+				t.Fatalf("err = %#v, want *APIError with TMDB's message", err)
+			}
+			if got := srv.Hits("/movie/" + tt.id); got != tt.hits {
+				t.Fatalf("upstream hits = %d, want %d", got, tt.hits)
+			}
+		})
+	}
+}
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.FailNext(1, http.StatusServiceUnavailable)
+	srv.FailNext(1, http.StatusTooManyRequests)
+
+	ctx, info := WithResponseInfo(context.Background())
+	details, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Title != "Fight Club" {
+		t.Fatalf("title = %q, want Fight Club", details.Title)
+	}
+	if got := info.Attempts(); got != 3 {
+		t.Fatalf("attempts = %d, want 3", got)
+	}
+}
+
+func TestClientHonorsRetryAfter(t *testing.T) {
+	c, srv := newTestClient(t, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}))
+	srv.RateLimitNext(1, time.Second)
+
+	start := time.Now()
+	if _, err := c.GetMovieDetails(context.Background(), tmdbtest.MovieID, Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %v, want at least the 1s Retry-After", elapsed)
+	}
+}
+
+func TestClientFailsFastOnLongRetryAfter(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.RateLimitNext(1, time.Minute)
+
+	_, err := c.GetMovieDetails(context.Background(), tmdbtest.MovieID, Locale{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Minute {
+		t.Fatalf("err = %v, want *APIError with a 1m RetryAfter", err)
+	}
+	if got := srv.Hits("/movie/550"); got != 1 {
+		t.Fatalf("upstream hits = %d, want 1", got)
+	}
+}
+
+func TestClientSkipsRetryPastCallerDeadline(t *testing.T) {
+	c, srv := newTestClient(t, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}))
+	srv.RateLimitNext(1, time.Second)
+
+	// The retry would outlast the caller, so it gets TMDB's answer now
+	// rather than a timeout later.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("err = %v, want a 429 *APIError", err)
+	}
+	if got := srv.Hits("/movie/550"); got != 1 {
+		t.Fatalf("upstream hits = %d, want 1", got)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	c, srv := newTestClient(t)
+	srv.SetLatency(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{})
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("err = %v, want a timeout *NetworkError", err)
+	}
+}
+
+func TestClientServesStaleOnError(t *testing.T) {
+	clock := newFakeClock()
+	c, srv := newTestClient(t,
+		WithClock(clock),
+		WithTTLPolicy(TTLPolicy{Default: time.Minute}),
+		WithStalePolicy(StalePolicy{IfError: time.Hour}),
+	)
+
+	if _, err := c.GetMovieDetails(context.Background(), tmdbtest.MovieID, Locale{}); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(10 * time.Minute)
+	srv.FailNext(3, http.StatusBadGateway)
+
+	ctx, info := WithResponseInfo(context.Background())
+	details, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{})
+	if err != nil {
+		t.Fatalf("stale entry not served: %v", err)
+	}
+	if details.ID != 550 || !info.Stale() {
+		t.Fatalf("got ID %d, stale %v; want 550 served stale", details.ID, info.Stale())
+	}
+
+	// Past the stale-if-error window the failure surfaces.
+	clock.Advance(2 * time.Hour)
+	srv.FailNext(3, http.StatusBadGateway)
+	if _, err := c.GetMovieDetails(context.Background(), tmdbtest.MovieID, Locale{}); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("err = %v, want ErrUnavailable", err)
+	}
+}
+
+func TestClientLocalizesRequests(t *testing.T) {
+	c, srv := newTestClient(t, WithLanguage("sw-KE"))
+	ctx := context.Background()
+
+	if _, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{Language: "fr-FR"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetPopularMovies(ctx, 1, Locale{Language: "pt-BR", Region: "BR"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each language is fetched and cached separately.
+	if got := srv.Hits("/movie/550"); got != 2 {
+		t.Fatalf("upstream hits = %d, want one per language", got)
+	}
+	reqs := srv.Requests()
+	for i, want := range []struct{ language, region string }{
+		{"sw-KE", ""},
+		{"fr-FR", ""},
+		{"pt-BR", "BR"},
+	} {
+		q := reqs[i].Query()
+		if q.Get("language") != want.language || q.Get("region") != want.region {
+			t.Errorf("request %d: language %q region %q, want %q and %q",
+				i, q.Get("language"), q.Get("region"), want.language, want.region)
+		}
+	}
+}
+
+func TestClientFiltersWatchProvidersByRegion(t *testing.T) {
+	c, srv := newTestClient(t, WithRegion("US"))
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		region string
+		want   string
+	}{
+		{"", "US"},
+		{"GB", "GB"},
+	} {
+		resp, err := c.GetMovieWatchProviders(ctx, tmdbtest.MovieID, Locale{Region: tt.region})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := resp.Results[tt.want]; !ok || len(resp.Results) != 1 {
+			t.Fatalf("region %q: got regions %v, want only %s", tt.region, resp.Results, tt.want)
+		}
+	}
+	if got := srv.Hits("/movie/550/watch/providers"); got != 1 {
+		t.Fatalf("upstream hits = %d, want 1 shared by every region", got)
+	}
+}
+
+func TestPersonCreditsNewestFirst(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	credits, err := c.GetPersonCredits(context.Background(), tmdbtest.PersonID, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, credit := range credits.Cast {
+		titles = append(titles, credit.Title+credit.Name)
+	}
+	want := []string{"Untitled Project", "Fight Club", "Se7en", "Friends"}
+	if !slices.Equal(titles, want) {
+		t.Fatalf("cast order = %q, want %q", titles, want)
+	}
+	if credits.Cast[1].Character != "Tyler Durden" || credits.Cast[3].EpisodeCount != 1 {
+		t.Fatalf("credit fields not decoded: %+v", credits.Cast)
+	}
+	if credits.Crew[0].Title != "12 Years a Slave" || credits.Crew[0].Job != "Producer" {
+		t.Fatalf("crew[0] = %+v, want the 2013 producer credit", credits.Crew[0])
+	}
+}
+
+func TestTVDetailsDecodesSchedule(t *testing.T) {
+	c, srv := newTestClient(t)
+	ctx := context.Background()
+
+	show, err := c.GetTVDetails(ctx, tmdbtest.TVID, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if show.NumberOfSeasons != 8 || show.Status != "Ended" || len(show.Seasons) == 0 || len(show.Networks) == 0 {
+		t.Fatalf("series details not decoded: %+v", show)
+	}
+	if show.LastEpisodeToAir == nil || show.LastEpisodeToAir.EpisodeType != "finale" || show.NextEpisodeToAir != nil {
+		t.Fatalf("air schedule = last %+v next %+v", show.LastEpisodeToAir, show.NextEpisodeToAir)
+	}
+
+	episode, err := c.GetTVEpisode(ctx, tmdbtest.TVID, 1, 1, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if episode.Name != "Winter Is Coming" || len(episode.Credits.Cast) == 0 || len(episode.Credits.GuestStars) == 0 {
+		t.Fatalf("episode not decoded: %+v", episode)
+	}
+	q := srv.Requests()[1].Query()
+	if q.Get("append_to_response") != "credits,images" || q.Get("include_image_language") != "en,null" {
+		t.Fatalf("episode query = %v", q)
+	}
+}
+
+func TestMovieDetailsAppendsSections(t *testing.T) {
+	c, srv := newTestClient(t)
+	ctx := context.Background()
+
+	movie, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{Region: "US"},
+		AppendWatchProviders, AppendCredits, AppendVideos, AppendCredits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movie.Title != "Fight Club" || len(movie.ProductionCompanies) == 0 || movie.BelongsToCollection != nil {
+		t.Fatalf("movie details not decoded: %+v", movie)
+	}
+	if movie.Credits == nil || len(movie.Credits.Cast) == 0 || movie.Videos == nil || movie.Images != nil {
+		t.Fatalf("appended sections = %+v", movie.Appended)
+	}
+	if movie.WatchProviders == nil || len(movie.WatchProviders.Results) != 1 {
+		t.Fatalf("watch providers not filtered to region: %+v", movie.WatchProviders)
+	}
+
+	q := srv.Requests()[0].Query()
+	if q.Get("append_to_response") != "credits,videos,watch/providers" || q.Get("include_video_language") != "en,en" {
+		t.Fatalf("details query = %v", q)
+	}
+
+	// The same sections in another order share the cache entry.
+	if _, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{Region: "US"}, AppendVideos, AppendWatchProviders, AppendCredits); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Fatalf("made %d upstream requests, want 1", n)
+	}
+
+	if _, err := c.GetMovieDetails(ctx, tmdbtest.MovieID, Locale{}, "reviews"); err == nil {
+		t.Fatal("expected an error for an unknown section")
+	}
+}
+
+func TestCollectionFromMovieDetails(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	movie, err := c.GetMovieDetails(ctx, tmdbtest.CollectionMovieID, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movie.BelongsToCollection == nil || strconv.Itoa(movie.BelongsToCollection.ID) != tmdbtest.CollectionID {
+		t.Fatalf("belongs_to_collection = %+v", movie.BelongsToCollection)
+	}
+
+	collection, err := c.GetCollection(ctx, tmdbtest.CollectionID, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, part := range collection.Parts {
+		titles = append(titles, part.Title)
+	}
+	want := []string{"The Godfather", "The Godfather Part II", "The Godfather Part III"}
+	if !slices.Equal(titles, want) {
+		t.Fatalf("parts = %q, want release order %q", titles, want)
+	}
+}
+
+func TestCompareReleaseOrder(t *testing.T) {
+	items := []MediaItem{
+		{ID: 1, ReleaseDate: "2001-05-01"},
+		{ID: 2},
+		{ID: 3, ReleaseDate: "1999-01-01"},
+		{ID: 4, FirstAirDate: "2000-01-01"},
+	}
+	slices.SortStableFunc(items, compareReleaseOrder)
+	var ids []int
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	if want := []int{3, 4, 1, 2}; !slices.Equal(ids, want) {
+		t.Fatalf("order = %v, want %v", ids, want)
+	}
+}
+
+func TestListsSendRegionForMoviesOnly(t *testing.T) {
+	c, srv := newTestClient(t)
+	ctx := context.Background()
+	loc := Locale{Language: "sw-KE", Region: "KE"}
+
+	nowPlaying, err := c.GetNowPlayingMovies(ctx, 2, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nowPlaying.Dates == nil || nowPlaying.Dates.Minimum == "" {
+		t.Fatalf("dates = %+v", nowPlaying.Dates)
+	}
+	if _, err := c.GetOnTheAirTV(ctx, 1, loc); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.Requests()
+	if q := reqs[0].Query(); q.Get("region") != "KE" || q.Get("page") != "2" {
+		t.Fatalf("now playing query = %v", q)
+	}
+	if q := reqs[1].Query(); q.Has("region") {
+		t.Fatalf("on the air query = %v, want no region", q)
+	}
+}
+
+func TestSearchByType(t *testing.T) {
+	c, srv := newTestClient(t)
+	ctx := context.Background()
+
+	movies, err := c.SearchMovies(ctx, "godfather", 1974, 1, Locale{Region: "NG"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies.Results) != 1 || movies.Results[0].Title != "The Godfather Part II" || movies.Results[0].MediaType != "movie" {
+		t.Fatalf("movie results = %+v", movies.Results)
+	}
+	if q := srv.Requests()[0].Query(); q.Get("year") != "1974" || q.Get("region") != "NG" {
+		t.Fatalf("movie search query = %v", q)
+	}
+
+	shows, err := c.SearchTV(ctx, "b", 2008, 1, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shows.Results) != 1 || shows.Results[0].Name != "Breaking Bad" || shows.Results[0].MediaType != "tv" {
+		t.Fatalf("tv results = %+v", shows.Results)
+	}
+	if q := srv.Requests()[1].Query(); q.Get("first_air_date_year") != "2008" || q.Has("year") {
+		t.Fatalf("tv search query = %v", q)
+	}
+
+	people, err := c.SearchPeople(ctx, "brad", 1, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(people.Results) != 1 || len(people.Results[0].KnownFor) == 0 || people.Results[0].KnownFor[0].MediaType != "movie" {
+		t.Fatalf("person results = %+v", people.Results)
+	}
+
+	companies, err := c.SearchCompanies(ctx, "ebony", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(companies.Results) != 1 || companies.Results[0].OriginCountry != "NG" || companies.Results[0].MediaType != "company" {
+		t.Fatalf("company results = %+v", companies.Results)
+	}
+	if q := srv.Requests()[3].Query(); q.Has("language") || q.Has("include_adult") {
+		t.Fatalf("company search query = %v", q)
+	}
+}
+
+func TestReviewsDecodeAuthors(t *testing.T) {
+	c, srv := newTestClient(t)
+
+	reviews, err := c.GetMovieReviews(context.Background(), tmdbtest.MovieID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Reviews in other languages are rare, so they are never filtered.
+	if q := srv.Requests()[0].Query(); q.Get("page") != "2" || q.Has("language") {
+		t.Fatalf("reviews query = %v", q)
+	}
+	if len(reviews.Results) != 2 {
+		t.Fatalf("got %d reviews, want 2", len(reviews.Results))
+	}
+
+	gravatar, rated := reviews.Results[0].AuthorDetails, reviews.Results[1].AuthorDetails
+	if !strings.HasPrefix(gravatar.AvatarPath, "https://") || gravatar.Rating != nil {
+		t.Fatalf("first author = %+v, want a Gravatar URL and no rating", gravatar)
+	}
+	if rated.AvatarPath != "/8cD0VUnqLkV3CAlXn9Eu8Mc0nQj.jpg" || rated.Rating == nil || *rated.Rating != 9 {
+		t.Fatalf("second author = %+v", rated)
+	}
+}
+
+func TestCertificationsNormalized(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		mediaType, country string
+		want               Certification
+	}{
+		// The theatrical release wins over the festival premiere and DVD.
+		{"movie", "US", Certification{Certification: "R", Order: 4, ReleaseDate: "1999-10-15T00:00:00.000Z"}},
+		// The theatrical release is unrated, so the Blu-ray one is used.
+		{"movie", "NG", Certification{Certification: "18", Order: 6, ReleaseDate: "2009-11-17T00:00:00.000Z"}},
+		{"movie", "FR", Certification{}},
+		{"tv", "US", Certification{Certification: "TV-MA", Order: 6}},
+		{"tv", "KE", Certification{Certification: "18", Order: 4}},
+		{"tv", "NG", Certification{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mediaType+"/"+tt.country, func(t *testing.T) {
+			var (
+				got *Certification
+				err error
+			)
+			if tt.mediaType == "movie" {
+				got, err = c.GetMovieCertification(ctx, tmdbtest.MovieID, tt.country)
+			} else {
+				got, err = c.GetTVCertification(ctx, tmdbtest.TVID, tt.country)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Country != tt.country || got.MediaType != tt.mediaType || got.Descriptors == nil {
+				t.Fatalf("certification = %+v", got)
+			}
+			if got.Certification != tt.want.Certification || got.Order != tt.want.Order || got.ReleaseDate != tt.want.ReleaseDate {
+				t.Fatalf("certification = %+v, want %+v", got, tt.want)
+			}
+			if (got.Meaning == "") != (tt.want.Certification == "") {
+				t.Fatalf("meaning = %q for certification %q", got.Meaning, got.Certification)
+			}
+		})
+	}
+}
+
+func TestFindByExternalID(t *testing.T) {
+	c, srv := newTestClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		source     ExternalSource
+		externalID string
+		mediaType  string
+		id         int
+	}{
+		{SourceIMDb, tmdbtest.MovieIMDbID, "movie", 550},
+		{SourceWikidata, tmdbtest.MovieWikidataID, "movie", 550},
+		{SourceIMDb, tmdbtest.TVIMDbID, "tv", 1399},
+		{SourceTVDB, tmdbtest.TVTVDBID, "tv", 1399},
+		{SourceIMDb, tmdbtest.PersonIMDbID, "person", 287},
+		{SourceIMDb, tmdbtest.EpisodeIMDbID, "tv_episode", 63056},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.source)+"/"+tt.externalID, func(t *testing.T) {
+			found, err := c.Find(ctx, tt.source, tt.externalID, Locale{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var mediaType string
+			var id int
+			switch {
+			case len(found.MovieResults) == 1:
+				mediaType, id = found.MovieResults[0].MediaType, found.MovieResults[0].ID
+			case len(found.TVResults) == 1:
+				mediaType, id = found.TVResults[0].MediaType, found.TVResults[0].ID
+			case len(found.PersonResults) == 1:
+				mediaType, id = found.PersonResults[0].MediaType, found.PersonResults[0].ID
+			case len(found.TVEpisodeResults) == 1:
+				mediaType, id = "tv_episode", found.TVEpisodeResults[0].ID
+				if found.TVEpisodeResults[0].ShowID != 1399 {
+					t.Fatalf("episode = %+v, want show 1399", found.TVEpisodeResults[0])
+				}
+			}
+			if mediaType != tt.mediaType || id != tt.id {
+				t.Fatalf("found %s %d, want %s %d", mediaType, id, tt.mediaType, tt.id)
+			}
+			req := srv.Requests()[len(srv.Requests())-1]
+			if req.Query().Get("external_source") != string(tt.source) {
+				t.Fatalf("find query = %v", req.Query())
+			}
+		})
+	}
+
+	found, err := c.Find(ctx, SourceIMDb, "tt0000001", Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.Empty() {
+		t.Fatalf("found %+v for an unknown ID", found)
+	}
+}
+
+func TestFindCachesMissesBriefly(t *testing.T) {
+	clock := newFakeClock()
+	c, srv := newTestClient(t, WithClock(clock), WithStalePolicy(StalePolicy{}))
+	ctx := context.Background()
+
+	find := func(externalID string) {
+		t.Helper()
+		if _, err := c.Find(ctx, SourceIMDb, externalID, Locale{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	const unknown = "tt0000001"
+	steps := []struct {
+		advance         time.Duration
+		misses, matches int
+	}{
+		{0, 1, 1},
+		{emptyFindTTL - time.Minute, 1, 1},
+		// The miss is asked again; the match is still good for a day.
+		{2 * time.Minute, 2, 1},
+		{25 * time.Hour, 3, 2},
+	}
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		find(unknown)
+		find(tmdbtest.MovieIMDbID)
+		if got := srv.Hits("/find/" + unknown); got != step.misses {
+			t.Fatalf("after %v, upstream finds for a miss = %d, want %d", step.advance, got, step.misses)
+		}
+		if got := srv.Hits("/find/" + tmdbtest.MovieIMDbID); got != step.matches {
+			t.Fatalf("after %v, upstream finds for a match = %d, want %d", step.advance, got, step.matches)
+		}
+	}
+
+	// The miss is written with its short lifetime in the first place, and
+	// callers are told so.
+	clock.Advance(25 * time.Hour)
+	infoCtx, info := WithResponseInfo(ctx)
+	if _, err := c.Find(infoCtx, SourceIMDb, unknown, Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	if maxAge, ok := info.MaxAge(); !ok || maxAge != emptyFindTTL {
+		t.Fatalf("max age = %v, %v; want %v", maxAge, ok, emptyFindTTL)
+	}
+}
+
+func TestFindRevalidatesMissesBriefly(t *testing.T) {
+	clock := newFakeClock()
+	c, srv := newTestClient(t, WithClock(clock), WithStalePolicy(StalePolicy{WhileRevalidate: time.Hour}))
+	ctx := context.Background()
+
+	const unknown = "tt0000001"
+	// Each find past the short TTL is served stale and refreshed in the
+	// background. Were a refresh cached for the usual day, the last find
+	// would be fresh and not reach TMDB.
+	for want := 1; want <= 3; want++ {
+		if _, err := c.Find(ctx, SourceIMDb, unknown, Locale{}); err != nil {
+			t.Fatal(err)
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for srv.Hits("/find/"+unknown) < want || c.flights.inFlight("/find/"+unknown+"external_source=imdb_id&language=en-US") {
+			if time.Now().After(deadline) {
+				t.Fatalf("upstream finds = %d, want %d", srv.Hits("/find/"+unknown), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(emptyFindTTL + time.Second)
+	}
+}
+
+func TestExternalSourceValid(t *testing.T) {
+	tests := []struct {
+		source ExternalSource
+		id     string
+		want   bool
+	}{
+		{SourceIMDb, "tt0137523", true},
+		{SourceIMDb, "nm0000093", true},
+		{SourceIMDb, "0137523", false},
+		{SourceTVDB, "121361", true},
+		{SourceTVDB, "tt0944947", false},
+		{SourceWikidata, "Q190050", true},
+		{SourceWikidata, "190050", false},
+		{SourceTwitter, "GameOfThrones", true},
+		{SourceTwitter, "", false},
+		{"letterboxd_id", "fight-club", false},
+	}
+	for _, tt := range tests {
+		if got := tt.source.Valid(tt.id); got != tt.want {
+			t.Errorf("%s.Valid(%q) = %v, want %v", tt.source, tt.id, got, tt.want)
+		}
+	}
+}
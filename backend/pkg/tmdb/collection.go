@@ -0,0 +1,63 @@
+package tmdb
+
+import (
+	"cmp"
+	"context"
+	"net/url"
+	"slices"
+)
+
+// Collection is a franchise, with its movies in release order.
+type Collection struct {
+	ID           int         `json:"id"`
+	Name         string      `json:"name"`
+	Overview     string      `json:"overview"`
+	PosterPath   string      `json:"poster_path"`
+	BackdropPath string      `json:"backdrop_path"`
+	Parts        []MediaItem `json:"parts"`
+}
+
+func (c *Client) GetCollection(ctx context.Context, id string, loc Locale) (*Collection, error) {
+	var response Collection
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/collection/"+id, params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// TMDB lists parts in no particular order.
+	slices.SortStableFunc(response.Parts, compareReleaseOrder)
+	return &response, nil
+}
+
+func (c *Client) GetCollectionImages(ctx context.Context, id string, loc Locale) (*ImagesResponse, error) {
+	var response ImagesResponse
+	loc = c.locale(loc)
+	params := url.Values{}
+	// Include textless images, which suit every language.
+	params.Set("include_image_language", primaryLanguage(loc.Language)+",null")
+
+	err := c.get(ctx, "/collection/"+id+"/images", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// compareReleaseOrder orders titles oldest first, with undated titles
+// (usually announced sequels) at the end.
+func compareReleaseOrder(a, b MediaItem) int {
+	da, db := a.date(), b.date()
+	switch {
+	case da == db:
+		return 0
+	case da == "":
+		return 1
+	case db == "":
+		return -1
+	}
+	return cmp.Compare(da, db)
+}
@@ -0,0 +1,249 @@
+package tmdb
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiscoverOptions filters and orders a discover query. Zero values leave a
+// filter out, so DiscoverOptions{} lists everything by popularity.
+type DiscoverOptions struct {
+	Genres        IDFilter
+	WithoutGenres []int
+	// From and To bound the release date of movies or the first air date
+	// of shows, inclusive. Only the date is used.
+	From, To       time.Time
+	MinVoteAverage float64
+	MaxVoteAverage float64
+	MinVoteCount   int
+	MaxVoteCount   int
+	// MinRuntime and MaxRuntime are in minutes; for shows they bound the
+	// episode runtime.
+	MinRuntime       int
+	MaxRuntime       int
+	OriginalLanguage string // ISO 639-1, e.g. "yo"
+	OriginCountry    string // ISO 3166-1, e.g. "NG"
+	Keywords         IDFilter
+	Companies        IDFilter
+	// WatchProviders and Monetization need a region, which defaults to the
+	// Locale's.
+	WatchProviders IDFilter
+	Monetization   []Monetization
+	WatchRegion    string
+	// MaxCertification keeps titles rated at most this in
+	// CertificationCountry, e.g. "PG-13" in "US". The country defaults to
+	// the Locale's region.
+	MaxCertification     string
+	CertificationCountry string
+	// SortBy is a field and direction, e.g. "vote_average.desc". It
+	// defaults to "popularity.desc".
+	SortBy string
+	Page   int
+}
+
+// IDFilter matches titles having all of IDs, or any of them when Any is
+// set.
+type IDFilter struct {
+	IDs []int
+	Any bool
+}
+
+// Monetization is a way a watch provider offers a title.
+type Monetization string
+
+const (
+	MonetizationFlatrate Monetization = "flatrate"
+	MonetizationFree     Monetization = "free"
+	MonetizationAds      Monetization = "ads"
+	MonetizationRent     Monetization = "rent"
+	MonetizationBuy      Monetization = "buy"
+)
+
+var monetizations = []Monetization{MonetizationFlatrate, MonetizationFree, MonetizationAds, MonetizationRent, MonetizationBuy}
+
+// sortFields lists the fields each media type can be sorted by.
+var sortFields = map[string][]string{
+	"movie": {"popularity", "vote_average", "vote_count", "primary_release_date", "revenue", "title", "original_title"},
+	"tv":    {"popularity", "vote_average", "vote_count", "first_air_date", "name", "original_name"},
+}
+
+// Validate reports the first option that TMDB would reject or ignore for
+// mediaType, "movie" or "tv". Filters needing a region must have one set;
+// DiscoverMovies and DiscoverTV fill it in from the Locale before they
+// validate.
+func (o DiscoverOptions) Validate(mediaType string) error {
+	fields, ok := sortFields[mediaType]
+	if !ok {
+		return fmt.Errorf("tmdb: cannot discover media type %q", mediaType)
+	}
+	if o.SortBy != "" {
+		field, dir, ok := strings.Cut(o.SortBy, ".")
+		if !ok || !slices.Contains(fields, field) || (dir != "asc" && dir != "desc") {
+			return fmt.Errorf("tmdb: cannot sort %s by %q", mediaType, o.SortBy)
+		}
+	}
+
+	switch {
+	case !o.From.IsZero() && !o.To.IsZero() && o.To.Before(o.From):
+		return errors.New("tmdb: date range ends before it starts")
+	case o.MinVoteAverage < 0 || o.MaxVoteAverage < 0 || o.MinVoteAverage > 10 || o.MaxVoteAverage > 10:
+		return errors.New("tmdb: vote average must be between 0 and 10")
+	case o.MaxVoteAverage > 0 && o.MaxVoteAverage < o.MinVoteAverage:
+		return errors.New("tmdb: vote average range ends before it starts")
+	case o.MinVoteCount < 0 || o.MaxVoteCount < 0 || (o.MaxVoteCount > 0 && o.MaxVoteCount < o.MinVoteCount):
+		return errors.New("tmdb: invalid vote count range")
+	case o.MinRuntime < 0 || o.MaxRuntime < 0 || (o.MaxRuntime > 0 && o.MaxRuntime < o.MinRuntime):
+		return errors.New("tmdb: invalid runtime range")
+	case o.Page < 0:
+		return errors.New("tmdb: invalid page")
+	}
+	for _, m := range o.Monetization {
+		if !slices.Contains(monetizations, m) {
+			return fmt.Errorf("tmdb: unknown monetization type %q", m)
+		}
+	}
+	if o.WatchRegion == "" && (len(o.WatchProviders.IDs) > 0 || len(o.Monetization) > 0) {
+		return errors.New("tmdb: watch provider filters need a region")
+	}
+	if o.CertificationCountry == "" && o.MaxCertification != "" {
+		return errors.New("tmdb: certification filter needs a country")
+	}
+	return nil
+}
+
+// DiscoverMovies lists movies matching opts.
+func (c *Client) DiscoverMovies(ctx context.Context, opts DiscoverOptions, loc Locale) (*TrendingResponse, error) {
+	var response TrendingResponse
+	loc = c.locale(loc)
+	params, err := opts.values("movie", loc)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("include_video", "true")
+	if loc.Region != "" {
+		params.Set("region", loc.Region)
+	}
+
+	err = c.get(ctx, "/discover/movie", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DiscoverTV lists shows matching opts.
+func (c *Client) DiscoverTV(ctx context.Context, opts DiscoverOptions, loc Locale) (*TrendingResponse, error) {
+	var response TrendingResponse
+	loc = c.locale(loc)
+	params, err := opts.values("tv", loc)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("include_null_first_air_dates", "false")
+
+	err = c.get(ctx, "/discover/tv", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// values encodes the options as TMDB's discover query parameters.
+func (o DiscoverOptions) values(mediaType string, loc Locale) (url.Values, error) {
+	o.WatchRegion = cmp.Or(o.WatchRegion, loc.Region)
+	o.CertificationCountry = cmp.Or(o.CertificationCountry, loc.Region)
+	if err := o.Validate(mediaType); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	// Adult titles have no certification, so a cap on it leaves them out.
+	if o.MaxCertification == "" {
+		params.Set("include_adult", "true")
+	}
+	params.Set("language", loc.Language)
+	params.Set("page", strconv.Itoa(max(o.Page, 1)))
+	params.Set("sort_by", cmp.Or(o.SortBy, "popularity.desc"))
+
+	setIDs(params, "with_genres", o.Genres)
+	setIDs(params, "without_genres", IDFilter{IDs: o.WithoutGenres})
+	setIDs(params, "with_keywords", o.Keywords)
+	setIDs(params, "with_companies", o.Companies)
+
+	date := "primary_release_date"
+	if mediaType == "tv" {
+		date = "first_air_date"
+	}
+	if !o.From.IsZero() {
+		params.Set(date+".gte", o.From.Format(time.DateOnly))
+	}
+	if !o.To.IsZero() {
+		params.Set(date+".lte", o.To.Format(time.DateOnly))
+	}
+
+	setFloat(params, "vote_average.gte", o.MinVoteAverage)
+	setFloat(params, "vote_average.lte", o.MaxVoteAverage)
+	setInt(params, "vote_count.gte", o.MinVoteCount)
+	setInt(params, "vote_count.lte", o.MaxVoteCount)
+	setInt(params, "with_runtime.gte", o.MinRuntime)
+	setInt(params, "with_runtime.lte", o.MaxRuntime)
+	if o.OriginalLanguage != "" {
+		params.Set("with_original_language", o.OriginalLanguage)
+	}
+	if o.OriginCountry != "" {
+		params.Set("with_origin_country", o.OriginCountry)
+	}
+
+	if len(o.WatchProviders.IDs) > 0 || len(o.Monetization) > 0 {
+		params.Set("watch_region", o.WatchRegion)
+		setIDs(params, "with_watch_providers", o.WatchProviders)
+		if len(o.Monetization) > 0 {
+			types := make([]string, len(o.Monetization))
+			for i, m := range o.Monetization {
+				types[i] = string(m)
+			}
+			params.Set("with_watch_monetization_types", strings.Join(types, "|"))
+		}
+	}
+
+	if o.MaxCertification != "" {
+		params.Set("certification_country", o.CertificationCountry)
+		params.Set("certification.lte", o.MaxCertification)
+	}
+	return params, nil
+}
+
+// setIDs joins f's IDs with "," for all or "|" for any, as TMDB expects.
+func setIDs(params url.Values, key string, f IDFilter) {
+	if len(f.IDs) == 0 {
+		return
+	}
+	sep := ","
+	if f.Any {
+		sep = "|"
+	}
+	ids := make([]string, len(f.IDs))
+	for i, id := range f.IDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	params.Set(key, strings.Join(ids, sep))
+}
+
+func setInt(params url.Values, key string, n int) {
+	if n != 0 {
+		params.Set(key, strconv.Itoa(n))
+	}
+}
+
+func setFloat(params url.Values, key string, f float64) {
+	if f != 0 {
+		params.Set(key, strconv.FormatFloat(f, 'f', -1, 64))
+	}
+}
@@ -0,0 +1,128 @@
+package tmdb
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDiscoverOptionsEncode(t *testing.T) {
+	loc := Locale{Language: "en-NG", Region: "NG"}
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		opts      DiscoverOptions
+		want      map[string]string
+	}{
+		{"defaults", "movie", DiscoverOptions{}, map[string]string{
+			"page": "1", "sort_by": "popularity.desc", "language": "en-NG", "with_genres": "", "include_adult": "true",
+		}},
+		{"all genres", "movie", DiscoverOptions{Genres: IDFilter{IDs: []int{18, 53}}}, map[string]string{
+			"with_genres": "18,53",
+		}},
+		{"any genre", "tv", DiscoverOptions{Genres: IDFilter{IDs: []int{18, 53}, Any: true}, WithoutGenres: []int{16}}, map[string]string{
+			"with_genres": "18|53", "without_genres": "16",
+		}},
+		{"movie dates", "movie", DiscoverOptions{From: date("1990-01-01"), To: date("1999-12-31")}, map[string]string{
+			"primary_release_date.gte": "1990-01-01", "primary_release_date.lte": "1999-12-31",
+		}},
+		{"tv dates", "tv", DiscoverOptions{From: date("2020-06-01")}, map[string]string{
+			"first_air_date.gte": "2020-06-01", "primary_release_date.gte": "",
+		}},
+		{"ranges", "movie", DiscoverOptions{MinVoteAverage: 7.5, MaxVoteAverage: 9, MinVoteCount: 100, MinRuntime: 80, MaxRuntime: 120}, map[string]string{
+			"vote_average.gte": "7.5", "vote_average.lte": "9", "vote_count.gte": "100", "vote_count.lte": "",
+			"with_runtime.gte": "80", "with_runtime.lte": "120",
+		}},
+		{"origin", "movie", DiscoverOptions{OriginalLanguage: "yo", OriginCountry: "NG", Keywords: IDFilter{IDs: []int{1721}}, Companies: IDFilter{IDs: []int{1, 2}, Any: true}}, map[string]string{
+			"with_original_language": "yo", "with_origin_country": "NG", "with_keywords": "1721", "with_companies": "1|2",
+		}},
+		{"watch providers", "movie", DiscoverOptions{WatchProviders: IDFilter{IDs: []int{8, 9}, Any: true}, Monetization: []Monetization{MonetizationFlatrate, MonetizationFree}}, map[string]string{
+			"with_watch_providers": "8|9", "watch_region": "NG", "with_watch_monetization_types": "flatrate|free",
+		}},
+		{"explicit watch region", "tv", DiscoverOptions{WatchProviders: IDFilter{IDs: []int{8}}, WatchRegion: "KE"}, map[string]string{
+			"watch_region": "KE",
+		}},
+		{"certification", "movie", DiscoverOptions{MaxCertification: "12A"}, map[string]string{
+			"certification.lte": "12A", "certification_country": "NG", "include_adult": "",
+		}},
+		{"certification country", "tv", DiscoverOptions{MaxCertification: "TV-14", CertificationCountry: "US"}, map[string]string{
+			"certification.lte": "TV-14", "certification_country": "US",
+		}},
+		{"sort and page", "tv", DiscoverOptions{SortBy: "first_air_date.desc", Page: 3}, map[string]string{
+			"sort_by": "first_air_date.desc", "page": "3",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.opts.values(tt.mediaType, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for key, want := range tt.want {
+				if got := params.Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscoverOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mediaType string
+		opts      DiscoverOptions
+	}{
+		{"media type", "person", DiscoverOptions{}},
+		{"sort field", "movie", DiscoverOptions{SortBy: "first_air_date.desc"}},
+		{"sort direction", "tv", DiscoverOptions{SortBy: "name.up"}},
+		{"date range", "movie", DiscoverOptions{From: time.Now(), To: time.Now().AddDate(-1, 0, 0)}},
+		{"vote average", "movie", DiscoverOptions{MinVoteAverage: 11}},
+		{"vote average range", "movie", DiscoverOptions{MinVoteAverage: 8, MaxVoteAverage: 6}},
+		{"vote count", "movie", DiscoverOptions{MinVoteCount: -1}},
+		{"runtime range", "tv", DiscoverOptions{MinRuntime: 60, MaxRuntime: 30}},
+		{"monetization", "movie", DiscoverOptions{Monetization: []Monetization{"stream"}}},
+		{"watch region", "movie", DiscoverOptions{WatchProviders: IDFilter{IDs: []int{8}}}},
+		{"certification country", "tv", DiscoverOptions{MaxCertification: "TV-14"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(tt.mediaType); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestDiscoverMatchesGenreShortcuts(t *testing.T) {
+	c, srv := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.GetMoviesByGenre(ctx, 878, 1, Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	// The same query through the builder is served from the cache.
+	movies, err := c.DiscoverMovies(ctx, DiscoverOptions{Genres: IDFilter{IDs: []int{878}}}, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(movies.Results))
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Fatalf("made %d upstream requests, want 1", n)
+	}
+
+	_, err = c.DiscoverTV(ctx, DiscoverOptions{WatchProviders: IDFilter{IDs: []int{8}}}, Locale{Language: "en"})
+	if err == nil {
+		t.Fatal("expected an error for watch providers without a region")
+	}
+	if q := srv.Requests()[0].Query(); q.Get("include_video") != "true" || q.Has("watch_region") {
+		t.Fatalf("discover query = %v", q)
+	}
+}
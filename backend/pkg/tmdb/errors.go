@@ -0,0 +1,130 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Sentinel errors for the broad classes of upstream failure. APIError and
+// NetworkError match them with errors.Is.
+var (
+	ErrNotFound     = errors.New("tmdb: not found")
+	ErrUnauthorized = errors.New("tmdb: unauthorized")
+	ErrRateLimited  = errors.New("tmdb: rate limited")
+	ErrUnavailable  = errors.New("tmdb: upstream unavailable")
+)
+
+// APIError is returned when TMDB answers with a non-200 status.
+type APIError struct {
+	Endpoint   string
+	StatusCode int
+	// Code and Message come from TMDB's JSON error body, when present.
+	Code    int
+	Message string
+	// RetryAfter is the delay requested by a 429 or 503 response, if any.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("tmdb: %s: status %d: %s", e.Endpoint, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("tmdb: %s: status %d", e.Endpoint, e.StatusCode)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUnavailable:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// NetworkError is returned when TMDB could not be reached or the response
+// could not be read, including when the request's context ends first.
+type NetworkError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("tmdb: %s: %v", e.Endpoint, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+func (e *NetworkError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+// Timeout reports whether the request failed because a deadline passed.
+func (e *NetworkError) Timeout() bool {
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// DecodeError is returned when a response body is not the JSON we expect.
+type DecodeError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("tmdb: %s: decoding response: %v", e.Endpoint, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+func newAPIError(endpoint string, resp *http.Response, now time.Time) *APIError {
+	apiErr := &APIError{
+		Endpoint:   endpoint,
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), now),
+	}
+
+	var body struct {
+		StatusCode    int    `json:"status_code"`
+		StatusMessage string `json:"status_message"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body); err == nil {
+		apiErr.Code = body.StatusCode
+		apiErr.Message = body.StatusMessage
+	}
+	return apiErr
+}
+
+// parseRetryAfter accepts both forms allowed by RFC 9110: delay-seconds and
+// an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// emptyFindTTL is how long a find that matched nothing is cached, so an ID
+// TMDB learns about later is picked up soon, unlike the day a match is kept.
+const emptyFindTTL = 5 * time.Minute
+
+// findTTL caches a find that matched nothing for at most emptyFindTTL.
+func findTTL(body []byte, ttl time.Duration) time.Duration {
+	var found FindResponse
+	if err := json.Unmarshal(body, &found); err == nil && found.Empty() {
+		return min(ttl, emptyFindTTL)
+	}
+	return ttl
+}
+
+// ExternalIDs are a title's or person's IDs on other sites. Each kind of
+// item has only some of them; the rest are empty.
+type ExternalIDs struct {
+	ID          int    `json:"id"`
+	IMDbID      string `json:"imdb_id,omitempty"`
+	TVDBID      int    `json:"tvdb_id,omitempty"`
+	TVRageID    int    `json:"tvrage_id,omitempty"`
+	WikidataID  string `json:"wikidata_id,omitempty"`
+	FacebookID  string `json:"facebook_id,omitempty"`
+	InstagramID string `json:"instagram_id,omitempty"`
+	TwitterID   string `json:"twitter_id,omitempty"`
+	TikTokID    string `json:"tiktok_id,omitempty"`
+	YouTubeID   string `json:"youtube_id,omitempty"`
+}
+
+// ExternalSource names the site an external ID comes from, as TMDB's find
+// endpoint expects it.
+type ExternalSource string
+
+const (
+	SourceIMDb      ExternalSource = "imdb_id"
+	SourceTVDB      ExternalSource = "tvdb_id"
+	SourceTVRage    ExternalSource = "tvrage_id"
+	SourceWikidata  ExternalSource = "wikidata_id"
+	SourceFacebook  ExternalSource = "facebook_id"
+	SourceInstagram ExternalSource = "instagram_id"
+	SourceTwitter   ExternalSource = "twitter_id"
+	SourceTikTok    ExternalSource = "tiktok_id"
+	SourceYouTube   ExternalSource = "youtube_id"
+)
+
+// externalIDFormats holds the shape of the IDs of sources that have one.
+// Social media handles are too loose to check.
+var externalIDFormats = map[ExternalSource]*regexp.Regexp{
+	SourceIMDb:     regexp.MustCompile(`^(tt|nm)\d{7,}$`),
+	SourceTVDB:     regexp.MustCompile(`^\d+$`),
+	SourceTVRage:   regexp.MustCompile(`^\d+$`),
+	SourceWikidata: regexp.MustCompile(`^Q\d+$`),
+}
+
+var externalSources = []ExternalSource{
+	SourceIMDb, SourceTVDB, SourceTVRage, SourceWikidata,
+	SourceFacebook, SourceInstagram, SourceTwitter, SourceTikTok, SourceYouTube,
+}
+
+// Valid reports whether id could be an ID from the source, e.g.
+// "tt0137523" for SourceIMDb.
+func (s ExternalSource) Valid(id string) bool {
+	if id == "" {
+		return false
+	}
+	if format, ok := externalIDFormats[s]; ok {
+		return format.MatchString(id)
+	}
+	for _, known := range externalSources {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// FindResponse holds the TMDB items matching an external ID. Usually only
+// one list has an item.
+type FindResponse struct {
+	MovieResults     []MovieResult  `json:"movie_results"`
+	TVResults        []TVResult     `json:"tv_results"`
+	PersonResults    []PersonResult `json:"person_results"`
+	TVSeasonResults  []FoundSeason  `json:"tv_season_results"`
+	TVEpisodeResults []Episode      `json:"tv_episode_results"`
+}
+
+// FoundSeason is a season matched by an external ID, with its show.
+type FoundSeason struct {
+	SeasonSummary
+	ShowID int `json:"show_id"`
+}
+
+// Empty reports whether nothing matched.
+func (f *FindResponse) Empty() bool {
+	return len(f.MovieResults)+len(f.TVResults)+len(f.PersonResults)+
+		len(f.TVSeasonResults)+len(f.TVEpisodeResults) == 0
+}
+
+// GetMovieExternalIDs returns a movie's IMDb, Wikidata and social media
+// IDs. TMDB does not localize them.
+func (c *Client) GetMovieExternalIDs(ctx context.Context, id string) (*ExternalIDs, error) {
+	return c.getExternalIDs(ctx, "/movie/"+id+"/external_ids")
+}
+
+// GetTVExternalIDs returns a show's IMDb, TVDB, Wikidata and social media
+// IDs.
+func (c *Client) GetTVExternalIDs(ctx context.Context, id string) (*ExternalIDs, error) {
+	return c.getExternalIDs(ctx, "/tv/"+id+"/external_ids")
+}
+
+func (c *Client) GetPersonExternalIDs(ctx context.Context, id string) (*ExternalIDs, error) {
+	return c.getExternalIDs(ctx, "/person/"+id+"/external_ids")
+}
+
+func (c *Client) getExternalIDs(ctx context.Context, endpoint string) (*ExternalIDs, error) {
+	var response ExternalIDs
+	params := url.Values{}
+
+	err := c.get(ctx, endpoint, params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Find looks up the TMDB items with an ID from another site.
+func (c *Client) Find(ctx context.Context, source ExternalSource, externalID string, loc Locale) (*FindResponse, error) {
+	var response FindResponse
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("external_source", string(source))
+	params.Set("language", loc.Language)
+
+	err := c.getTTL(ctx, "/find/"+url.PathEscape(externalID), params, &response, findTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	// TMDB tags most but not all of these; make every result say what it is.
+	for i := range response.MovieResults {
+		response.MovieResults[i].MediaType = "movie"
+	}
+	for i := range response.TVResults {
+		response.TVResults[i].MediaType = "tv"
+	}
+	for i := range response.PersonResults {
+		response.PersonResults[i].MediaType = "person"
+	}
+	return &response, nil
+}
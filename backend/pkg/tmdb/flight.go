@@ -0,0 +1,182 @@
+package tmdb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup coalesces concurrent fetches for the same key into a single
+// upstream request, in the spirit of x/sync/singleflight. Unlike
+// singleflight, each caller waits under its own context, and the shared
+// request is only cancelled once every caller waiting on it has gone away,
+// or once the latest deadline among them has passed.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	val     []byte
+	err     error
+	waiters int
+	ctx     *flightContext
+}
+
+// do runs fn once for all concurrent callers with the same key. shared
+// reports whether the caller joined a fetch started by someone else.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) (val []byte, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if call, ok := g.calls[key]; ok && call.ctx.extend(ctx) {
+		call.waiters++
+		g.mu.Unlock()
+		val, err = g.wait(ctx, key, call)
+		return val, true, err
+	}
+
+	fctx := newFlightContext(ctx)
+	call := &flightCall{
+		done:    make(chan struct{}),
+		waiters: 1,
+		ctx:     fctx,
+	}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	go func() {
+		call.val, call.err = fn(fctx)
+		g.mu.Lock()
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		fctx.release()
+		close(call.done)
+	}()
+
+	val, err = g.wait(ctx, key, call)
+	return val, false, err
+}
+
+// inFlight reports whether a fetch for key is currently running.
+func (g *flightGroup) inFlight(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.calls[key]
+	return ok
+}
+
+func (g *flightGroup) wait(ctx context.Context, key string, call *flightCall) ([]byte, error) {
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+	}
+
+	g.mu.Lock()
+	call.waiters--
+	if call.waiters == 0 {
+		call.ctx.release()
+		// Later callers must start a fresh fetch rather than join one
+		// that is being torn down.
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+	}
+	g.mu.Unlock()
+	return nil, ctx.Err()
+}
+
+// flightContext is the context a shared fetch runs under. It outlives the
+// caller that started the fetch but keeps that caller's values (such as a
+// ResponseInfo), and its deadline is the latest among the callers waiting
+// on it, so retries and rate limiting can still plan around a deadline.
+type flightContext struct {
+	context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	deadline time.Time
+	timer    *time.Timer // nil while some caller has no deadline
+	expired  bool
+}
+
+func newFlightContext(ctx context.Context) *flightContext {
+	fctx := &flightContext{}
+	fctx.Context, fctx.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	if deadline, ok := ctx.Deadline(); ok {
+		fctx.deadline = deadline
+		fctx.timer = time.AfterFunc(time.Until(deadline), fctx.expire)
+	}
+	return fctx
+}
+
+// extend pushes the deadline out to ctx's, if that is later, or drops it
+// if ctx has none. It reports false once the fetch has been cancelled or
+// has expired, as a new caller should start a fresh one instead.
+func (c *flightContext) extend(ctx context.Context) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Context.Err() != nil {
+		return false
+	}
+	if c.timer == nil {
+		return true
+	}
+
+	deadline, ok := ctx.Deadline()
+	if ok && !deadline.After(c.deadline) {
+		return true
+	}
+	if !c.timer.Stop() {
+		return false
+	}
+	if !ok {
+		c.timer = nil
+		c.deadline = time.Time{}
+		return true
+	}
+	c.deadline = deadline
+	c.timer.Reset(time.Until(deadline))
+	return true
+}
+
+// release cancels the fetch and stops its deadline timer.
+func (c *flightContext) release() {
+	c.mu.Lock()
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	c.mu.Unlock()
+	c.cancel()
+}
+
+func (c *flightContext) expire() {
+	c.mu.Lock()
+	c.expired = true
+	c.mu.Unlock()
+	c.cancel()
+}
+
+func (c *flightContext) Deadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadline, c.timer != nil
+}
+
+func (c *flightContext) Err() error {
+	err := c.Context.Err()
+	if err == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.expired {
+		return context.DeadlineExceeded
+	}
+	return err
+}
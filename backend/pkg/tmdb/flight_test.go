@@ -0,0 +1,284 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until n callers are waiting on in-flight fetches.
+func waitForWaiters(t *testing.T, c *Client, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.flights.mu.Lock()
+		waiting := 0
+		for _, call := range c.flights.calls {
+			waiting += call.waiters
+		}
+		c.flights.mu.Unlock()
+		if waiting == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d in-flight callers", n)
+}
+
+func TestGetCoalescesConcurrentRequests(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Write([]byte(`{"page":1,"results":[{"id":550,"title":"Fight Club"}],"total_pages":1,"total_results":1}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("token", WithBaseURL(srv.URL))
+	defer c.Close()
+
+	const callers = 50
+	var wg sync.WaitGroup
+	results := make([]*TrendingResponse, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = c.GetTrendingMovies(context.Background(), "week", Locale{})
+		}()
+	}
+
+	waitForWaiters(t, c, callers)
+	close(release)
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("upstream hits = %d, want 1", got)
+	}
+	for i := range callers {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: %v", i, errs[i])
+		}
+		if len(results[i].Results) != 1 || results[i].Results[0].ID != 550 {
+			t.Fatalf("caller %d: unexpected results %+v", i, results[i])
+		}
+	}
+
+	// The response is cached now, so another call stays local.
+	if _, err := c.GetTrendingMovies(context.Background(), "week", Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("upstream hits after cached call = %d, want 1", got)
+	}
+}
+
+func TestGetCoalescingSurvivesCallerCancellation(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Write([]byte(`{"id":550,"title":"Fight Club"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("token", WithBaseURL(srv.URL))
+	defer c.Close()
+
+	// The caller that starts the fetch gives up first.
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetMovieDetails(ctx, "550", Locale{})
+		firstErr <- err
+	}()
+	waitForWaiters(t, c, 1)
+
+	type result struct {
+		item *MovieDetails
+		err  error
+	}
+	second := make(chan result, 1)
+	go func() {
+		item, err := c.GetMovieDetails(context.Background(), "550", Locale{})
+		second <- result{item, err}
+	}()
+	waitForWaiters(t, c, 2)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller err = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	res := <-second
+	if res.err != nil {
+		t.Fatalf("remaining caller: %v", res.err)
+	}
+	if res.item.ID != 550 {
+		t.Fatalf("remaining caller got ID %d, want 550", res.item.ID)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Fatalf("upstream hits = %d, want 1", got)
+	}
+}
+
+func TestGetCancelsUpstreamWhenAllCallersLeave(t *testing.T) {
+	started := make(chan struct{})
+	upstreamDone := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		upstreamDone <- r.Context().Err()
+	}))
+	defer srv.Close()
+
+	c := NewClient("token", WithBaseURL(srv.URL))
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := c.GetMovieDetails(ctx, "550", Locale{})
+			errc <- err
+		}()
+	}
+	waitForWaiters(t, c, 2)
+	<-started
+	cancel()
+
+	for range 2 {
+		var netErr *NetworkError
+		if err := <-errc; !errors.As(err, &netErr) {
+			t.Fatalf("err = %v, want *NetworkError", err)
+		}
+	}
+	select {
+	case <-upstreamDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream request was not cancelled")
+	}
+}
+
+// joinFlight starts a do call for key under ctx and waits until it is one
+// of n callers on the fetch.
+func joinFlight(t *testing.T, g *flightGroup, ctx context.Context, key string, n int, fn func(context.Context) ([]byte, error)) <-chan error {
+	t.Helper()
+	errc := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(ctx, key, fn)
+		errc <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		call := g.calls[key]
+		joined := call != nil && call.waiters == n
+		g.mu.Unlock()
+		if joined {
+			return errc
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d callers on %s", n, key)
+	return nil
+}
+
+func TestFlightDeadlineIsTheLatestCallers(t *testing.T) {
+	now := time.Now()
+	early, cancel := context.WithDeadline(context.Background(), now.Add(time.Minute))
+	defer cancel()
+	late, cancel := context.WithDeadline(context.Background(), now.Add(2*time.Minute))
+	defer cancel()
+
+	tests := []struct {
+		name    string
+		callers []context.Context
+		want    time.Time // zero for no deadline
+	}{
+		{"one caller", []context.Context{early}, now.Add(time.Minute)},
+		{"later caller extends", []context.Context{early, late}, now.Add(2 * time.Minute)},
+		{"earlier caller does not shorten", []context.Context{late, early}, now.Add(2 * time.Minute)},
+		{"caller without deadline lifts it", []context.Context{early, context.Background(), late}, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g flightGroup
+			release := make(chan struct{})
+			type deadline struct {
+				at time.Time
+				ok bool
+			}
+			got := make(chan deadline, 1)
+			fn := func(ctx context.Context) ([]byte, error) {
+				<-release
+				at, ok := ctx.Deadline()
+				got <- deadline{at, ok}
+				return nil, nil
+			}
+
+			var errs []<-chan error
+			for i, ctx := range tt.callers {
+				errs = append(errs, joinFlight(t, &g, ctx, "key", i+1, fn))
+			}
+			close(release)
+			for _, errc := range errs {
+				if err := <-errc; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			d := <-got
+			if d.ok != !tt.want.IsZero() || !d.at.Equal(tt.want) {
+				t.Fatalf("Deadline() = %v, %v; want %v", d.at, d.ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlightExpiresAtTheLatestDeadline(t *testing.T) {
+	var g flightGroup
+	fetchErr := make(chan error, 1)
+	fn := func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		fetchErr <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	start := time.Now()
+	short, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	long, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	first := joinFlight(t, &g, short, "key", 1, fn)
+	second := joinFlight(t, &g, long, "key", 2, fn)
+
+	if err := <-first; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("first caller err = %v, want context.DeadlineExceeded", err)
+	}
+	if err := <-second; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second caller err = %v, want context.DeadlineExceeded", err)
+	}
+	<-fetchErr
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("fetch ended after %v, before the later caller's deadline", elapsed)
+	}
+
+	// Expiry reads as a deadline, like any other context's.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	fctx := newFlightContext(ctx)
+	<-fctx.Done()
+	if err := fctx.Err(); err != context.DeadlineExceeded {
+		t.Fatalf("Err() = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,100 @@
+package tmdb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ResponseInfo collects details about the upstream work done on behalf of
+// a context, for callers that want to log or surface them. A single
+// ResponseInfo may be shared by several concurrent client calls.
+type ResponseInfo struct {
+	mu        sync.Mutex
+	attempts  int
+	retries   int
+	maxAge    time.Duration
+	hasMaxAge bool
+	stale     bool
+}
+
+type responseInfoKey struct{}
+
+// WithResponseInfo returns a context that records upstream activity into
+// the returned ResponseInfo.
+func WithResponseInfo(ctx context.Context) (context.Context, *ResponseInfo) {
+	info := &ResponseInfo{}
+	return context.WithValue(ctx, responseInfoKey{}, info), info
+}
+
+// ResponseInfoFrom returns the ResponseInfo attached to ctx, or nil.
+func ResponseInfoFrom(ctx context.Context) *ResponseInfo {
+	info, _ := ctx.Value(responseInfoKey{}).(*ResponseInfo)
+	return info
+}
+
+// Attempts is the number of HTTP requests sent to TMDB.
+func (i *ResponseInfo) Attempts() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.attempts
+}
+
+// Retries is the number of those requests that were retries.
+func (i *ResponseInfo) Retries() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.retries
+}
+
+// MaxAge is how much longer the least fresh response used remains valid
+// in the cache. ok is false if no response has been recorded.
+func (i *ResponseInfo) MaxAge() (maxAge time.Duration, ok bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.maxAge, i.hasMaxAge
+}
+
+// Stale reports whether any response used was served from the cache past
+// its TTL, either while being refreshed or because TMDB was failing.
+func (i *ResponseInfo) Stale() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.stale
+}
+
+func (i *ResponseInfo) recordStale() {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.stale = true
+	i.maxAge = 0
+	i.hasMaxAge = true
+}
+
+func (i *ResponseInfo) recordMaxAge(d time.Duration) {
+	if i == nil {
+		return
+	}
+	d = max(d, 0)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if !i.hasMaxAge || d < i.maxAge {
+		i.maxAge = d
+		i.hasMaxAge = true
+	}
+}
+
+func (i *ResponseInfo) recordAttempt(retry bool) {
+	if i == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.attempts++
+	if retry {
+		i.retries++
+	}
+}
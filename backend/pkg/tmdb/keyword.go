@@ -0,0 +1,55 @@
+package tmdb
+
+import (
+	"context"
+	"net/url"
+)
+
+type Keyword struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// KeywordsResponse lists the keywords tagging a title.
+type KeywordsResponse struct {
+	ID       int       `json:"id"`
+	Keywords []Keyword `json:"keywords"`
+}
+
+// GetMovieKeywords returns a movie's keywords. TMDB does not localize them.
+func (c *Client) GetMovieKeywords(ctx context.Context, id string) (*KeywordsResponse, error) {
+	var response KeywordsResponse
+	params := url.Values{}
+
+	err := c.get(ctx, "/movie/"+id+"/keywords", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetTVKeywords returns a show's keywords, in the same shape as a movie's.
+func (c *Client) GetTVKeywords(ctx context.Context, id string) (*KeywordsResponse, error) {
+	// TMDB lists a show's keywords under results rather than keywords.
+	var response struct {
+		ID      int       `json:"id"`
+		Results []Keyword `json:"results"`
+	}
+	params := url.Values{}
+
+	err := c.get(ctx, "/tv/"+id+"/keywords", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &KeywordsResponse{ID: response.ID, Keywords: response.Results}, nil
+}
+
+// GetMoviesByKeyword lists the most popular movies tagged with a keyword.
+func (c *Client) GetMoviesByKeyword(ctx context.Context, keywordID int, page int, loc Locale) (*TrendingResponse, error) {
+	return c.DiscoverMovies(ctx, DiscoverOptions{Keywords: IDFilter{IDs: []int{keywordID}}, Page: page}, loc)
+}
+
+// GetTVByKeyword lists the most popular shows tagged with a keyword.
+func (c *Client) GetTVByKeyword(ctx context.Context, keywordID int, page int, loc Locale) (*TrendingResponse, error) {
+	return c.DiscoverTV(ctx, DiscoverOptions{Keywords: IDFilter{IDs: []int{keywordID}}, Page: page}, loc)
+}
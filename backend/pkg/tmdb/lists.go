@@ -0,0 +1,71 @@
+package tmdb
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ListResponse is a page of one of TMDB's curated lists.
+type ListResponse struct {
+	TrendingResponse
+	// Dates is the release window covered by the now playing and upcoming
+	// movie lists, and nil for the others.
+	Dates *DateRange `json:"dates,omitempty"`
+}
+
+// DateRange is an inclusive span of dates, formatted YYYY-MM-DD.
+type DateRange struct {
+	Minimum string `json:"minimum"`
+	Maximum string `json:"maximum"`
+}
+
+// GetNowPlayingMovies returns movies in theatres in loc's region.
+func (c *Client) GetNowPlayingMovies(ctx context.Context, page int, loc Locale) (*ListResponse, error) {
+	return c.getList(ctx, "/movie/now_playing", page, loc, true)
+}
+
+// GetUpcomingMovies returns movies soon to be released in loc's region.
+func (c *Client) GetUpcomingMovies(ctx context.Context, page int, loc Locale) (*ListResponse, error) {
+	return c.getList(ctx, "/movie/upcoming", page, loc, true)
+}
+
+// GetTopRatedMovies returns the highest-rated movies of all time. Like the
+// other movie lists it is filtered by loc's region.
+func (c *Client) GetTopRatedMovies(ctx context.Context, page int, loc Locale) (*ListResponse, error) {
+	return c.getList(ctx, "/movie/top_rated", page, loc, true)
+}
+
+// GetAiringTodayTV returns shows with an episode airing today. TMDB does
+// not filter TV lists by region.
+func (c *Client) GetAiringTodayTV(ctx context.Context, page int, loc Locale) (*ListResponse, error) {
+	return c.getList(ctx, "/tv/airing_today", page, loc, false)
+}
+
+// GetOnTheAirTV returns shows with an episode airing in the next seven
+// days.
+func (c *Client) GetOnTheAirTV(ctx context.Context, page int, loc Locale) (*ListResponse, error) {
+	return c.getList(ctx, "/tv/on_the_air", page, loc, false)
+}
+
+// GetTopRatedTV returns the highest-rated shows of all time.
+func (c *Client) GetTopRatedTV(ctx context.Context, page int, loc Locale) (*ListResponse, error) {
+	return c.getList(ctx, "/tv/top_rated", page, loc, false)
+}
+
+func (c *Client) getList(ctx context.Context, endpoint string, page int, loc Locale, regional bool) (*ListResponse, error) {
+	var response ListResponse
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+	params.Set("page", strconv.Itoa(page))
+	if regional && loc.Region != "" {
+		params.Set("region", loc.Region)
+	}
+
+	err := c.get(ctx, endpoint, params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
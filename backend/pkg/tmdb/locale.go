@@ -0,0 +1,40 @@
+package tmdb
+
+import "strings"
+
+// Locale is the language and region a response is localized for. Empty
+// fields fall back to the client's defaults.
+type Locale struct {
+	// Language is an ISO 639-1 code, optionally with a region, e.g. "fr"
+	// or "pt-BR".
+	Language string
+	// Region is an ISO 3166-1 code, e.g. "KE". It filters release dates
+	// and watch providers where TMDB supports that.
+	Region string
+}
+
+// WithRegion sets the region used when a call's Locale names none. By
+// default responses are not filtered by region.
+func WithRegion(region string) Option {
+	return func(c *Client) {
+		c.region = region
+	}
+}
+
+// locale fills in loc's empty fields from the client's defaults.
+func (c *Client) locale(loc Locale) Locale {
+	if loc.Language == "" {
+		loc.Language = c.language
+	}
+	if loc.Region == "" {
+		loc.Region = c.region
+	}
+	return loc
+}
+
+// primaryLanguage strips any region from a language tag, as TMDB's
+// include_*_language filters expect.
+func primaryLanguage(lang string) string {
+	primary, _, _ := strings.Cut(lang, "-")
+	return primary
+}
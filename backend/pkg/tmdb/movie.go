@@ -0,0 +1,145 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+type MovieDetails struct {
+	ID                  int                `json:"id"`
+	IMDbID              string             `json:"imdb_id"`
+	Title               string             `json:"title"`
+	OriginalTitle       string             `json:"original_title"`
+	OriginalLanguage    string             `json:"original_language"`
+	Overview            string             `json:"overview"`
+	Tagline             string             `json:"tagline"`
+	Status              string             `json:"status"`
+	ReleaseDate         string             `json:"release_date"`
+	Runtime             int                `json:"runtime"`
+	Budget              int64              `json:"budget"`
+	Revenue             int64              `json:"revenue"`
+	Homepage            string             `json:"homepage"`
+	PosterPath          string             `json:"poster_path"`
+	BackdropPath        string             `json:"backdrop_path"`
+	Adult               bool               `json:"adult"`
+	Video               bool               `json:"video"`
+	Popularity          float64            `json:"popularity"`
+	VoteAverage         float64            `json:"vote_average"`
+	VoteCount           int                `json:"vote_count"`
+	Genres              []Genre            `json:"genres"`
+	ProductionCompanies []Company          `json:"production_companies"`
+	ProductionCountries []Country          `json:"production_countries"`
+	SpokenLanguages     []SpokenLanguage   `json:"spoken_languages"`
+	BelongsToCollection *CollectionSummary `json:"belongs_to_collection"`
+	Appended
+}
+
+type Company struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	LogoPath      string `json:"logo_path"`
+	OriginCountry string `json:"origin_country"`
+}
+
+type Country struct {
+	ISO3166_1 string `json:"iso_3166_1"`
+	Name      string `json:"name"`
+}
+
+type SpokenLanguage struct {
+	ISO639_1    string `json:"iso_639_1"`
+	Name        string `json:"name"`
+	EnglishName string `json:"english_name"`
+}
+
+// CollectionSummary identifies the collection a movie belongs to.
+type CollectionSummary struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	PosterPath   string `json:"poster_path"`
+	BackdropPath string `json:"backdrop_path"`
+}
+
+// Sections of a title that can be fetched along with its details, using
+// TMDB's append_to_response.
+const (
+	AppendCredits         = "credits"
+	AppendVideos          = "videos"
+	AppendImages          = "images"
+	AppendRecommendations = "recommendations"
+	AppendWatchProviders  = "watch/providers"
+	AppendExternalIDs     = "external_ids"
+)
+
+var appendable = []string{AppendCredits, AppendVideos, AppendImages, AppendRecommendations, AppendWatchProviders, AppendExternalIDs}
+
+// Appended holds the sections requested along with a title's details.
+// Sections that were not requested are nil.
+type Appended struct {
+	Credits         *CreditsResponse        `json:"credits,omitempty"`
+	Videos          *VideoResponse          `json:"videos,omitempty"`
+	Images          *ImagesResponse         `json:"images,omitempty"`
+	Recommendations *TrendingResponse       `json:"recommendations,omitempty"`
+	WatchProviders  *WatchProvidersResponse `json:"watch/providers,omitempty"`
+	ExternalIDs     *ExternalIDs            `json:"external_ids,omitempty"`
+}
+
+// ValidAppend reports whether section can be appended to a title's
+// details.
+func ValidAppend(section string) bool {
+	return slices.Contains(appendable, section)
+}
+
+// setAppend asks TMDB for the given sections along with the details. The
+// list is sorted so the same sections always share a cache entry.
+func setAppend(params url.Values, loc Locale, sections []string) error {
+	if len(sections) == 0 {
+		return nil
+	}
+	for _, s := range sections {
+		if !ValidAppend(s) {
+			return fmt.Errorf("tmdb: cannot append %q to details", s)
+		}
+	}
+	sections = slices.Compact(slices.Sorted(slices.Values(sections)))
+	params.Set("append_to_response", strings.Join(sections, ","))
+
+	// The same filters as the standalone endpoints.
+	if slices.Contains(sections, AppendVideos) {
+		params.Set("include_video_language", primaryLanguage(loc.Language)+",en")
+	}
+	if slices.Contains(sections, AppendImages) {
+		params.Set("include_image_language", primaryLanguage(loc.Language)+",null")
+	}
+	return nil
+}
+
+// forRegion applies the region filter of the standalone watch providers
+// endpoint.
+func (a *Appended) forRegion(region string) {
+	if a.WatchProviders != nil {
+		a.WatchProviders = a.WatchProviders.forRegion(region)
+	}
+}
+
+// GetMovieDetails returns a movie's details, together with any of the
+// Append* sections named in appends.
+func (c *Client) GetMovieDetails(ctx context.Context, id string, loc Locale, appends ...string) (*MovieDetails, error) {
+	var response MovieDetails
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+	if err := setAppend(params, loc, appends); err != nil {
+		return nil, err
+	}
+
+	err := c.get(ctx, "/movie/"+id, params, &response)
+	if err != nil {
+		return nil, err
+	}
+	response.forRegion(loc.Region)
+	return &response, nil
+}
@@ -0,0 +1,62 @@
+package tmdb
+
+import (
+	"net/http"
+	"time"
+)
+
+// Clock tells the client what time it is, for cache freshness and
+// Retry-After dates. Tests can substitute a fake.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// WithHTTPClient makes the client send requests through hc, or through a
+// default client if hc is nil. Later WithTimeout or WithTransport options
+// apply to a copy, so hc itself is never modified; earlier ones are
+// discarded along with the client they configured.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc == nil {
+			hc = &http.Client{Timeout: defaultTimeout}
+		}
+		c.httpClient = hc
+	}
+}
+
+// WithTransport sends requests through rt, e.g. a proxying or recording
+// transport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Transport = rt
+		c.httpClient = &hc
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent to TMDB.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithLanguage sets the language requested from TMDB, as an IETF tag such
+// as "fr-FR".
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.language = lang
+	}
+}
+
+// WithClock replaces the client's source of time. The built-in cache
+// backends check expiry against it too.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
@@ -0,0 +1,88 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport counts the requests it passes on.
+type countingTransport struct {
+	n atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.n.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestHTTPClientOptionOrder(t *testing.T) {
+	rt := &countingTransport{}
+
+	tests := []struct {
+		name          string
+		opts          func(hc *http.Client) []Option
+		wantTimeout   time.Duration
+		wantTransport http.RoundTripper
+	}{
+		{"defaults", func(*http.Client) []Option { return nil }, defaultTimeout, nil},
+		{"client only", func(hc *http.Client) []Option {
+			return []Option{WithHTTPClient(hc)}
+		}, time.Second, nil},
+		{"timeout and transport after client", func(hc *http.Client) []Option {
+			return []Option{WithHTTPClient(hc), WithTimeout(2 * time.Second), WithTransport(rt)}
+		}, 2 * time.Second, rt},
+		{"client replaces earlier timeout and transport", func(hc *http.Client) []Option {
+			return []Option{WithTimeout(2 * time.Second), WithTransport(rt), WithHTTPClient(hc)}
+		}, time.Second, nil},
+		{"nil client", func(*http.Client) []Option {
+			return []Option{WithHTTPClient(nil)}
+		}, defaultTimeout, nil},
+		{"timeout and transport after nil client", func(*http.Client) []Option {
+			return []Option{WithHTTPClient(nil), WithTimeout(2 * time.Second), WithTransport(rt)}
+		}, 2 * time.Second, rt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc := &http.Client{Timeout: time.Second}
+			c := NewClient("token", tt.opts(hc)...)
+			defer c.Close()
+
+			if c.httpClient.Timeout != tt.wantTimeout {
+				t.Errorf("timeout = %v, want %v", c.httpClient.Timeout, tt.wantTimeout)
+			}
+			if c.httpClient.Transport != tt.wantTransport {
+				t.Errorf("transport = %v, want %v", c.httpClient.Transport, tt.wantTransport)
+			}
+			if hc.Timeout != time.Second || hc.Transport != nil {
+				t.Errorf("caller's client was modified: %+v", hc)
+			}
+		})
+	}
+}
+
+func TestRequestsUseTransportAndUserAgent(t *testing.T) {
+	var userAgent atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent.Store(r.UserAgent())
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	rt := &countingTransport{}
+	c := NewClient("token", WithBaseURL(srv.URL), WithHTTPClient(nil), WithTransport(rt), WithUserAgent("tamasha-test"))
+	defer c.Close()
+
+	if _, err := c.GetMovieDetails(context.Background(), "550", Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := rt.n.Load(); got != 1 {
+		t.Errorf("transport saw %d requests, want 1", got)
+	}
+	if got := userAgent.Load(); got != "tamasha-test" {
+		t.Errorf("User-Agent = %v, want tamasha-test", got)
+	}
+}
@@ -0,0 +1,124 @@
+package tmdb
+
+import (
+	"cmp"
+	"context"
+	"net/url"
+	"slices"
+)
+
+type Person struct {
+	ID                 int      `json:"id"`
+	Name               string   `json:"name"`
+	AlsoKnownAs        []string `json:"also_known_as"`
+	Biography          string   `json:"biography"`
+	Birthday           string   `json:"birthday"`
+	Deathday           string   `json:"deathday"`
+	PlaceOfBirth       string   `json:"place_of_birth"`
+	Gender             int      `json:"gender"`
+	KnownForDepartment string   `json:"known_for_department"`
+	ProfilePath        string   `json:"profile_path"`
+	Homepage           string   `json:"homepage"`
+	IMDbID             string   `json:"imdb_id"`
+	Popularity         float64  `json:"popularity"`
+	Adult              bool     `json:"adult"`
+}
+
+// PersonCredits is a person's filmography across movies and TV, newest
+// first.
+type PersonCredits struct {
+	ID   int                `json:"id"`
+	Cast []PersonCastCredit `json:"cast"`
+	Crew []PersonCrewCredit `json:"crew"`
+}
+
+type PersonCastCredit struct {
+	MediaItem
+	Character string `json:"character"`
+	CreditID  string `json:"credit_id"`
+	// EpisodeCount is set for TV credits only.
+	EpisodeCount int `json:"episode_count,omitempty"`
+}
+
+type PersonCrewCredit struct {
+	MediaItem
+	Job          string `json:"job"`
+	Department   string `json:"department"`
+	CreditID     string `json:"credit_id"`
+	EpisodeCount int    `json:"episode_count,omitempty"`
+}
+
+type PersonImages struct {
+	ID       int     `json:"id"`
+	Profiles []Image `json:"profiles"`
+}
+
+func (c *Client) GetPerson(ctx context.Context, id string, loc Locale) (*Person, error) {
+	var response Person
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/person/"+id, params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) GetPersonCredits(ctx context.Context, id string, loc Locale) (*PersonCredits, error) {
+	var response PersonCredits
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/person/"+id+"/combined_credits", params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// TMDB returns credits in no useful order.
+	slices.SortStableFunc(response.Cast, func(a, b PersonCastCredit) int {
+		return compareNewestFirst(a.MediaItem, b.MediaItem)
+	})
+	slices.SortStableFunc(response.Crew, func(a, b PersonCrewCredit) int {
+		return compareNewestFirst(a.MediaItem, b.MediaItem)
+	})
+	return &response, nil
+}
+
+// GetPersonImages returns a person's profile photos. Unlike posters they
+// carry no language, so there is nothing to localize.
+func (c *Client) GetPersonImages(ctx context.Context, id string) (*PersonImages, error) {
+	var response PersonImages
+	params := url.Values{}
+
+	err := c.get(ctx, "/person/"+id+"/images", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// compareNewestFirst orders titles by release or first air date, latest
+// first, with undated titles (usually unreleased projects) at the front.
+func compareNewestFirst(a, b MediaItem) int {
+	da, db := a.date(), b.date()
+	switch {
+	case da == db:
+		return 0
+	case da == "":
+		return -1
+	case db == "":
+		return 1
+	}
+	return cmp.Compare(db, da)
+}
+
+// date returns a movie's release date or a show's first air date.
+func (m MediaItem) date() string {
+	if m.ReleaseDate != "" {
+		return m.ReleaseDate
+	}
+	return m.FirstAirDate
+}
@@ -0,0 +1,37 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// WithRateLimit throttles all requests made by the client, including
+// retries, to rps requests per second with bursts of up to burst. Callers
+// over the limit wait their turn rather than hitting TMDB's 429s. A
+// non-positive rps disables limiting.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		if rps <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(rps), max(burst, 1))
+	}
+}
+
+func (c *Client) waitForToken(ctx context.Context, endpoint string) error {
+	if c.limiter == nil {
+		return nil
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return &NetworkError{Endpoint: endpoint, Err: ctx.Err()}
+		}
+		// The limiter refuses up front when the wait would outlast the
+		// context's deadline.
+		return &NetworkError{Endpoint: endpoint, Err: fmt.Errorf("%w: %v", context.DeadlineExceeded, err)}
+	}
+	return nil
+}
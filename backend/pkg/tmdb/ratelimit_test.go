@@ -0,0 +1,102 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newStubServer answers every request with an empty object, recording
+// when each one arrived.
+func newStubServer(t *testing.T) (*httptest.Server, func() []time.Time) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		arrivals []time.Time
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(arrivals)
+	}
+}
+
+func TestRateLimitSpreadsBursts(t *testing.T) {
+	const (
+		rps   = 50
+		burst = 2
+		calls = 6
+	)
+	srv, arrivals := newStubServer(t)
+	c := NewClient("token", WithBaseURL(srv.URL), WithRateLimit(rps, burst))
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Distinct IDs, so no call is answered from another's
+			// response.
+			if _, err := c.GetMovieDetails(context.Background(), strconv.Itoa(i+1), Locale{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := arrivals()
+	if len(got) != calls {
+		t.Fatalf("upstream got %d requests, want %d", len(got), calls)
+	}
+	slices.SortFunc(got, func(a, b time.Time) int { return a.Compare(b) })
+
+	// The burst goes straight through; each call after it waits a token.
+	interval := time.Second / rps
+	if d := got[burst].Sub(got[0]); d < interval*9/10 {
+		t.Fatalf("call %d after the burst went out after %v, want at least %v", burst+1, d, interval)
+	}
+	want := (calls - burst) * interval
+	if d := got[calls-1].Sub(got[0]); d < want*9/10 {
+		t.Fatalf("%d calls took %v, want at least %v at %d rps", calls, d, want, rps)
+	}
+}
+
+func TestRateLimitWaitHonorsCancellation(t *testing.T) {
+	srv, arrivals := newStubServer(t)
+	c := NewClient("token", WithBaseURL(srv.URL), WithRateLimit(0.1, 1))
+	defer c.Close()
+
+	// Use up the only token; the next one is ten seconds away.
+	if _, err := c.GetMovieDetails(context.Background(), "1", Locale{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	_, err := c.GetMovieDetails(ctx, "2", Locale{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("cancelled caller waited %v for a token", elapsed)
+	}
+	if n := len(arrivals()); n != 1 {
+		t.Fatalf("upstream got %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,192 @@
+package tmdb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRecorded is returned in replay mode for a request that has no
+// recording in the cassette directory.
+var ErrNotRecorded = errors.New("tmdb: request not recorded")
+
+// RecorderMode selects whether a Recorder captures or replays traffic.
+type RecorderMode int
+
+const (
+	// Record sends requests upstream and saves each response.
+	Record RecorderMode = iota
+	// Replay answers requests from saved responses only.
+	Replay
+)
+
+// Recorder is an http.RoundTripper that saves TMDB responses to a cassette
+// directory and serves them back later, so the API can run offline. Use it
+// with WithTransport.
+//
+// Each recording is a JSON file named after the request path and query.
+// Request headers, including the token, are never written.
+type Recorder struct {
+	// OnMiss, if set, is called for each request that has no recording in
+	// Replay mode.
+	OnMiss func(req *http.Request)
+
+	dir  string
+	mode RecorderMode
+	next http.RoundTripper
+}
+
+// NewRecorder returns a Recorder using the cassette directory dir. In
+// Record mode requests go through next, or http.DefaultTransport if it is
+// nil; in Replay mode next is unused.
+func NewRecorder(dir string, mode RecorderMode, next http.RoundTripper) (*Recorder, error) {
+	switch mode {
+	case Record:
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	case Replay:
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("tmdb: cassette directory: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("tmdb: unknown recorder mode %d", mode)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, mode: mode, next: next}, nil
+}
+
+// recording is the on-disk form of one request and its response.
+type recording struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// recordedHeaders are the response headers worth keeping.
+var recordedHeaders = []string{"Content-Type", "Retry-After"}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == Replay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// path maps a request onto its recording. The path keeps cassettes easy to
+// browse; the hash tells apart requests that differ only in their query.
+func (r *Recorder) path(req *http.Request) string {
+	key := req.Method + " " + req.URL.Path + "?" + req.URL.Query().Encode()
+	sum := sha256.Sum256([]byte(key))
+	name := strings.ReplaceAll(strings.Trim(req.URL.Path, "/"), "/", "_")
+	return filepath.Join(r.dir, name+"-"+hex.EncodeToString(sum[:4])+".json")
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	b, err := os.ReadFile(r.path(req))
+	if errors.Is(err, fs.ErrNotExist) {
+		if r.OnMiss != nil {
+			r.OnMiss(req)
+		}
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, req.Method, req.URL.RequestURI())
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rec recording
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, fmt.Errorf("tmdb: reading recording for %s: %w", req.URL.RequestURI(), err)
+	}
+
+	header := rec.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// Rate limiting and server errors are transient; replaying them would
+	// only make the cassette less useful.
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || !json.Valid(body) {
+		return resp, nil
+	}
+
+	rec := recording{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Status: resp.StatusCode,
+		Body:   body,
+	}
+	for _, name := range recordedHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			if rec.Header == nil {
+				rec.Header = make(http.Header)
+			}
+			rec.Header.Set(name, v)
+		}
+	}
+	if err := r.save(r.path(req), rec); err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("tmdb: saving recording for %s: %w", req.URL.RequestURI(), err)
+	}
+	return resp, nil
+}
+
+// save writes the recording atomically, so a concurrent replay never sees
+// a partial file.
+func (r *Recorder) save(path string, rec recording) error {
+	b, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(r.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(b, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"afroflix/pkg/tmdb/tmdbtest"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	dir := t.TempDir()
+	srv := tmdbtest.NewServer()
+
+	recorder, err := NewRecorder(dir, Record, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient("secret-token", WithBaseURL(srv.URL), WithTransport(recorder), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	if _, err := c.GetMovieCredits(context.Background(), tmdbtest.MovieID, Locale{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetMovieDetails(context.Background(), "1", Locale{}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	srv.FailNext(1, http.StatusBadGateway)
+	if _, err := c.GetTVDetails(context.Background(), tmdbtest.TVID, Locale{}); err == nil {
+		t.Fatal("injected failure did not surface")
+	}
+	c.Close()
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("recorded %d files, want 2 (the 502 must not be recorded)", len(files))
+	}
+	for _, f := range files {
+		b, _ := os.ReadFile(f)
+		if strings.Contains(string(b), "secret-token") {
+			t.Fatalf("%s contains the token", f)
+		}
+	}
+
+	// Replay needs no token and no server.
+	player, err := NewRecorder(dir, Replay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = NewClient("", WithBaseURL(srv.URL), WithTransport(player))
+	defer c.Close()
+
+	credits, err := c.GetMovieCredits(context.Background(), tmdbtest.MovieID, Locale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(credits.Cast) == 0 || credits.Cast[0].Name != "Edward Norton" {
+		t.Fatalf("replayed credits = %+v", credits)
+	}
+	if _, err := c.GetMovieDetails(context.Background(), "1", Locale{}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("replayed err = %v, want ErrNotFound", err)
+	}
+
+	ctx, info := WithResponseInfo(context.Background())
+	_, err = c.GetTVDetails(ctx, tmdbtest.TVID, Locale{})
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("err = %v, want ErrNotRecorded", err)
+	}
+	if got := info.Attempts(); got != 1 {
+		t.Fatalf("attempts = %d, want 1 (misses must not be retried)", got)
+	}
+}
+
+func TestRecorderReplayNeedsCassettes(t *testing.T) {
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing"), Replay, nil); err == nil {
+		t.Fatal("replaying from a missing directory succeeded")
+	}
+}
@@ -0,0 +1,95 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed upstream requests are retried. Only
+// idempotent requests are retried, and only for rate limiting, 5xx
+// responses and network errors.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on each
+	// subsequent retry up to MaxDelay. The actual delay is jittered.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// OnRetry, if set, is called before sleeping ahead of each retry.
+	OnRetry func(endpoint string, attempt int, err error, delay time.Duration)
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// WithRetryPolicy replaces the client's retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// backoff returns how long to wait after the given failed attempt, and
+// whether another attempt should be made at all.
+func (p RetryPolicy) backoff(ctx context.Context, req *http.Request, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return 0, false
+	}
+
+	var retryAfter time.Duration
+	var apiErr *APIError
+	var netErr *NetworkError
+	switch {
+	case errors.As(err, &apiErr):
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			retryAfter = apiErr.RetryAfter
+		default:
+			return 0, false
+		}
+	case errors.As(err, &netErr):
+		// A missing recording will still be missing next time.
+		if errors.Is(err, ErrNotRecorded) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	// Full jitter: a random delay between zero and the exponential cap.
+	ceiling := p.BaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	var delay time.Duration
+	if ceiling > 0 {
+		delay = rand.N(ceiling)
+	}
+
+	// A server-requested delay wins, but we will not wait longer than
+	// MaxDelay for it; in that case the caller is better off failing fast.
+	if retryAfter > 0 {
+		if retryAfter > p.MaxDelay {
+			return 0, false
+		}
+		delay = max(delay, retryAfter)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return 0, false
+	}
+	return delay, true
+}
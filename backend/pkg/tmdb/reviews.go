@@ -0,0 +1,62 @@
+package tmdb
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type Review struct {
+	ID            string        `json:"id"`
+	Author        string        `json:"author"`
+	AuthorDetails AuthorDetails `json:"author_details"`
+	Content       string        `json:"content"`
+	CreatedAt     string        `json:"created_at"`
+	UpdatedAt     string        `json:"updated_at"`
+	URL           string        `json:"url"`
+}
+
+type AuthorDetails struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	// AvatarPath is either a TMDB image path or a full URL, e.g. to
+	// Gravatar.
+	AvatarPath string `json:"avatar_path"`
+	// Rating is out of 10, and nil when the author gave none.
+	Rating *float64 `json:"rating"`
+}
+
+// GetMovieReviews returns a page of a movie's user reviews, in whatever
+// language they were written. TMDB filters reviews by the language asked
+// for, and nearly all are in English, so none is asked for.
+func (c *Client) GetMovieReviews(ctx context.Context, id string, page int) (*Page[Review], error) {
+	return c.getReviews(ctx, "/movie/"+id+"/reviews", page)
+}
+
+// GetTVReviews returns a page of a show's user reviews. Like movie
+// reviews, they are not filtered by language.
+func (c *Client) GetTVReviews(ctx context.Context, id string, page int) (*Page[Review], error) {
+	return c.getReviews(ctx, "/tv/"+id+"/reviews", page)
+}
+
+func (c *Client) getReviews(ctx context.Context, endpoint string, page int) (*Page[Review], error) {
+	var response Page[Review]
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(page))
+
+	err := c.get(ctx, endpoint, params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// TMDB prefixes external avatar URLs with a slash, as if they were
+	// image paths: "/https://secure.gravatar.com/avatar/...".
+	for i := range response.Results {
+		details := &response.Results[i].AuthorDetails
+		if strings.HasPrefix(details.AvatarPath, "/http") {
+			details.AvatarPath = details.AvatarPath[1:]
+		}
+	}
+	return &response, nil
+}
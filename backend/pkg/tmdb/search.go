@@ -0,0 +1,203 @@
+package tmdb
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Page is one page of a paginated TMDB response.
+type Page[T any] struct {
+	Page         int `json:"page"`
+	Results      []T `json:"results"`
+	TotalPages   int `json:"total_pages"`
+	TotalResults int `json:"total_results"`
+}
+
+// The search results below carry a MediaType like SearchMulti's, which
+// TMDB leaves out of its per-type searches, so results can be told apart
+// once mixed.
+
+type MovieResult struct {
+	MediaType        string  `json:"media_type"`
+	ID               int     `json:"id"`
+	Title            string  `json:"title"`
+	OriginalTitle    string  `json:"original_title"`
+	OriginalLanguage string  `json:"original_language"`
+	Overview         string  `json:"overview"`
+	ReleaseDate      string  `json:"release_date"`
+	PosterPath       string  `json:"poster_path"`
+	BackdropPath     string  `json:"backdrop_path"`
+	GenreIDs         []int   `json:"genre_ids"`
+	Popularity       float64 `json:"popularity"`
+	VoteAverage      float64 `json:"vote_average"`
+	VoteCount        int     `json:"vote_count"`
+	Adult            bool    `json:"adult"`
+}
+
+type TVResult struct {
+	MediaType        string   `json:"media_type"`
+	ID               int      `json:"id"`
+	Name             string   `json:"name"`
+	OriginalName     string   `json:"original_name"`
+	OriginalLanguage string   `json:"original_language"`
+	OriginCountry    []string `json:"origin_country"`
+	Overview         string   `json:"overview"`
+	FirstAirDate     string   `json:"first_air_date"`
+	PosterPath       string   `json:"poster_path"`
+	BackdropPath     string   `json:"backdrop_path"`
+	GenreIDs         []int    `json:"genre_ids"`
+	Popularity       float64  `json:"popularity"`
+	VoteAverage      float64  `json:"vote_average"`
+	VoteCount        int      `json:"vote_count"`
+}
+
+type PersonResult struct {
+	MediaType          string  `json:"media_type"`
+	ID                 int     `json:"id"`
+	Name               string  `json:"name"`
+	OriginalName       string  `json:"original_name"`
+	Gender             int     `json:"gender"`
+	KnownForDepartment string  `json:"known_for_department"`
+	ProfilePath        string  `json:"profile_path"`
+	Popularity         float64 `json:"popularity"`
+	// KnownFor mixes movies and shows, told apart by MediaType.
+	KnownFor []MediaItem `json:"known_for"`
+}
+
+type CompanyResult struct {
+	MediaType string `json:"media_type"`
+	Company
+}
+
+type KeywordResult struct {
+	MediaType string `json:"media_type"`
+	Keyword
+}
+
+type CollectionResult struct {
+	MediaType string `json:"media_type"`
+	CollectionSummary
+	OriginalName     string `json:"original_name"`
+	OriginalLanguage string `json:"original_language"`
+	Overview         string `json:"overview"`
+}
+
+// SearchMovies finds movies by title, released in year unless it is 0.
+func (c *Client) SearchMovies(ctx context.Context, query string, year, page int, loc Locale) (*Page[MovieResult], error) {
+	var response Page[MovieResult]
+	loc = c.locale(loc)
+	params := searchParams(query, page)
+	params.Set("include_adult", "true")
+	params.Set("language", loc.Language)
+	if year != 0 {
+		params.Set("year", strconv.Itoa(year))
+	}
+	if loc.Region != "" {
+		params.Set("region", loc.Region)
+	}
+
+	err := c.get(ctx, "/search/movie", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Results {
+		response.Results[i].MediaType = "movie"
+	}
+	return &response, nil
+}
+
+// SearchTV finds shows by name, first aired in firstAirDateYear unless it
+// is 0.
+func (c *Client) SearchTV(ctx context.Context, query string, firstAirDateYear, page int, loc Locale) (*Page[TVResult], error) {
+	var response Page[TVResult]
+	loc = c.locale(loc)
+	params := searchParams(query, page)
+	params.Set("include_adult", "true")
+	params.Set("language", loc.Language)
+	if firstAirDateYear != 0 {
+		params.Set("first_air_date_year", strconv.Itoa(firstAirDateYear))
+	}
+
+	err := c.get(ctx, "/search/tv", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Results {
+		response.Results[i].MediaType = "tv"
+	}
+	return &response, nil
+}
+
+func (c *Client) SearchPeople(ctx context.Context, query string, page int, loc Locale) (*Page[PersonResult], error) {
+	var response Page[PersonResult]
+	loc = c.locale(loc)
+	params := searchParams(query, page)
+	params.Set("include_adult", "true")
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/search/person", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Results {
+		response.Results[i].MediaType = "person"
+	}
+	return &response, nil
+}
+
+// SearchCompanies finds production companies by name. TMDB does not
+// localize them.
+func (c *Client) SearchCompanies(ctx context.Context, query string, page int) (*Page[CompanyResult], error) {
+	var response Page[CompanyResult]
+	params := searchParams(query, page)
+
+	err := c.get(ctx, "/search/company", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Results {
+		response.Results[i].MediaType = "company"
+	}
+	return &response, nil
+}
+
+// SearchKeywords finds keywords by name. TMDB does not localize them.
+func (c *Client) SearchKeywords(ctx context.Context, query string, page int) (*Page[KeywordResult], error) {
+	var response Page[KeywordResult]
+	params := searchParams(query, page)
+
+	err := c.get(ctx, "/search/keyword", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Results {
+		response.Results[i].MediaType = "keyword"
+	}
+	return &response, nil
+}
+
+func (c *Client) SearchCollections(ctx context.Context, query string, page int, loc Locale) (*Page[CollectionResult], error) {
+	var response Page[CollectionResult]
+	loc = c.locale(loc)
+	params := searchParams(query, page)
+	params.Set("include_adult", "true")
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/search/collection", params, &response)
+	if err != nil {
+		return nil, err
+	}
+	for i := range response.Results {
+		response.Results[i].MediaType = "collection"
+	}
+	return &response, nil
+}
+
+// searchParams returns the parameters every search takes.
+func searchParams(query string, page int) url.Values {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("page", strconv.Itoa(page))
+	return params
+}
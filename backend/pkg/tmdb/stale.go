@@ -0,0 +1,62 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// StalePolicy keeps cache entries past their TTL so they can still be
+// used, in the spirit of HTTP's stale-while-revalidate and stale-if-error.
+type StalePolicy struct {
+	// WhileRevalidate is how long after expiry an entry is served
+	// immediately while a fresh copy is fetched in the background.
+	WhileRevalidate time.Duration
+	// IfError is how long after expiry an entry is served when fetching a
+	// fresh copy fails because TMDB is unavailable or rate limiting us.
+	IfError time.Duration
+}
+
+func DefaultStalePolicy() StalePolicy {
+	return StalePolicy{
+		WhileRevalidate: time.Minute,
+		IfError:         time.Hour,
+	}
+}
+
+// WithStalePolicy replaces the client's stale-serving windows. A zero
+// policy never serves stale data.
+func WithStalePolicy(p StalePolicy) Option {
+	return func(c *Client) {
+		c.stale = p
+	}
+}
+
+// retention is how long past expiry an entry must be kept.
+func (p StalePolicy) retention() time.Duration {
+	return max(p.WhileRevalidate, p.IfError, 0)
+}
+
+func (p StalePolicy) canRevalidate(entry CacheEntry, now time.Time) bool {
+	return now.Before(entry.Expires.Add(p.WhileRevalidate))
+}
+
+func (p StalePolicy) canServeOnError(entry CacheEntry, now time.Time, err error) bool {
+	if !now.Before(entry.Expires.Add(p.IfError)) {
+		return false
+	}
+	return errors.Is(err, ErrUnavailable) || errors.Is(err, ErrRateLimited)
+}
+
+// revalidate refreshes a stale entry in the background, unless a fetch for
+// it is already under way. It is detached from the request that noticed
+// the entry was stale, which has already been answered.
+func (c *Client) revalidate(endpoint string, params url.Values, cacheKey string, adjust ttlFunc) {
+	if c.flights.inFlight(cacheKey) {
+		return
+	}
+	go c.flights.do(context.Background(), cacheKey, func(ctx context.Context) ([]byte, error) {
+		return c.fetch(ctx, endpoint, params, cacheKey, adjust)
+	})
+}
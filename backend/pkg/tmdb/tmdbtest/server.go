@@ -0,0 +1,347 @@
+// Package tmdbtest provides a fake TMDB API for tests and offline
+// development. It answers the endpoints tmdb.Client uses from fixture
+// files, and can be told to slow down or fail.
+//
+// Point a client at it with tmdb.WithBaseURL:
+//
+//	srv := tmdbtest.NewServer()
+//	defer srv.Close()
+//	client := tmdb.NewClient("token", tmdb.WithBaseURL(srv.URL))
+package tmdbtest
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture IDs present in the bundled data. Any other ID is answered with
+// TMDB's 404.
+const (
+	MovieID      = "550"  // Fight Club
+	TVID         = "1399" // Game of Thrones
+	PersonID     = "287"  // Brad Pitt
+	CollectionID = "230"  // The Godfather Collection
+	// CollectionMovieID is a movie that belongs to CollectionID.
+	CollectionMovieID = "238" // The Godfather
+)
+
+// External IDs that TMDB's find endpoint resolves to the fixtures above.
+// Any other ID finds nothing.
+const (
+	MovieIMDbID     = "tt0137523" // MovieID
+	MovieWikidataID = "Q190050"   // MovieID
+	TVIMDbID        = "tt0944947" // TVID
+	TVTVDBID        = "121361"    // TVID
+	PersonIMDbID    = "nm0000093" // PersonID
+	EpisodeIMDbID   = "tt1480055" // TVID's first episode
+)
+
+// Handler serves the fake API. Its methods are safe to call while requests
+// are in flight.
+type Handler struct {
+	mu        sync.Mutex
+	fixtures  fs.FS
+	overrides map[string][]byte
+	latency   time.Duration
+	faults    []fault
+	requests  []*url.URL
+}
+
+type fault struct {
+	status     int
+	retryAfter time.Duration
+}
+
+// NewHandler returns a Handler serving the bundled fixtures.
+func NewHandler() *Handler {
+	sub, _ := fs.Sub(fixtures, "fixtures")
+	return &Handler{
+		fixtures:  sub,
+		overrides: make(map[string][]byte),
+	}
+}
+
+// Server is a Handler listening on a local port.
+type Server struct {
+	*httptest.Server
+	*Handler
+}
+
+// NewServer starts a Server. Callers should Close it when done.
+func NewServer() *Server {
+	h := NewHandler()
+	return &Server{Server: httptest.NewServer(h), Handler: h}
+}
+
+// SetLatency delays every response by d, or until the request is
+// cancelled.
+func (h *Handler) SetLatency(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.latency = d
+}
+
+// FailNext answers the next n requests with status and TMDB's error body.
+func (h *Handler) FailNext(n int, status int) {
+	h.addFaults(n, fault{status: status})
+}
+
+// RateLimitNext answers the next n requests with 429 and a Retry-After of
+// retryAfter, rounded up to whole seconds.
+func (h *Handler) RateLimitNext(n int, retryAfter time.Duration) {
+	h.addFaults(n, fault{status: http.StatusTooManyRequests, retryAfter: retryAfter})
+}
+
+func (h *Handler) addFaults(n int, f fault) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for range n {
+		h.faults = append(h.faults, f)
+	}
+}
+
+// SetFixture serves body for the TMDB path, e.g. "/movie/550", in place of
+// the bundled fixture. A nil body removes the override.
+func (h *Handler) SetFixture(path string, body []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if body == nil {
+		delete(h.overrides, path)
+		return
+	}
+	h.overrides[path] = body
+}
+
+// Requests returns the URL of every request received so far, in order.
+func (h *Handler) Requests() []*url.URL {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*url.URL(nil), h.requests...)
+}
+
+// Hits counts the requests received for the TMDB path, ignoring the query.
+func (h *Handler) Hits(path string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for _, u := range h.requests {
+		if u.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.requests = append(h.requests, r.URL)
+	latency := h.latency
+	var f *fault
+	if len(h.faults) > 0 {
+		f = &h.faults[0]
+		h.faults = h.faults[1:]
+	}
+	h.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+
+	if f != nil {
+		if f.retryAfter > 0 {
+			secs := int((f.retryAfter + time.Second - 1) / time.Second)
+			w.Header().Set("Retry-After", strconv.Itoa(secs))
+		}
+		sendStatus(w, f.status)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		sendStatus(w, http.StatusMethodNotAllowed)
+		return
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); !ok || token == "" {
+		sendStatus(w, http.StatusUnauthorized)
+		return
+	}
+
+	body, ok := h.fixture(r.URL.Path)
+	if !ok && strings.HasPrefix(r.URL.Path, "/find/") {
+		// TMDB finds nothing rather than failing for unknown IDs.
+		body, ok = []byte(emptyFind), true
+	}
+	if !ok {
+		sendStatus(w, http.StatusNotFound)
+		return
+	}
+	if v := r.URL.Query().Get("append_to_response"); v != "" {
+		body = h.appendSections(r.URL.Path, body, strings.Split(v, ","))
+	}
+
+	// Emulate the filtering TMDB does for the endpoints that take it.
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/search/"):
+		q := r.URL.Query()
+		query := strings.ToLower(q.Get("query"))
+		year := q.Get("year") + q.Get("first_air_date_year")
+		body = filterResults(body, func(item map[string]any) bool {
+			title, _ := item["title"].(string)
+			name, _ := item["name"].(string)
+			released, _ := item["release_date"].(string)
+			aired, _ := item["first_air_date"].(string)
+			return (strings.Contains(strings.ToLower(title), query) ||
+				strings.Contains(strings.ToLower(name), query)) &&
+				strings.HasPrefix(released+aired, year)
+		})
+	case strings.HasPrefix(r.URL.Path, "/discover/"):
+		q := r.URL.Query()
+		minVote, _ := strconv.ParseFloat(q.Get("vote_average.gte"), 64)
+		body = filterResults(body, func(item map[string]any) bool {
+			vote, _ := item["vote_average"].(float64)
+			return vote >= minVote &&
+				matchGenres(item, q.Get("with_genres"), true) &&
+				matchGenres(item, q.Get("without_genres"), false)
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// fixture returns the body to serve for a TMDB path, if there is one.
+func (h *Handler) fixture(path string) ([]byte, bool) {
+	h.mu.Lock()
+	body, ok := h.overrides[path]
+	h.mu.Unlock()
+	if ok {
+		return body, true
+	}
+	body, err := fs.ReadFile(h.fixtures, fixtureName(path))
+	return body, err == nil
+}
+
+// appendSections emulates append_to_response by adding the fixture for
+// each sub-resource, e.g. "/movie/550/credits", under its name. Sections
+// without a fixture are left out, as TMDB leaves out unknown ones.
+func (h *Handler) appendSections(path string, body []byte, sections []string) []byte {
+	var details map[string]json.RawMessage
+	if err := json.Unmarshal(body, &details); err != nil {
+		return body
+	}
+	for _, section := range sections {
+		if sub, ok := h.fixture(path + "/" + section); ok {
+			details[section] = sub
+		}
+	}
+	b, err := json.Marshal(details)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// matchGenres reports whether item has the genres in filter, which TMDB
+// separates with "," for all of them or "|" for any. With want false it
+// reports whether item has none of them instead.
+func matchGenres(item map[string]any, filter string, want bool) bool {
+	if filter == "" {
+		return true
+	}
+	has := map[int]bool{}
+	ids, _ := item["genre_ids"].([]any)
+	for _, id := range ids {
+		if n, ok := id.(float64); ok {
+			has[int(n)] = true
+		}
+	}
+
+	anyOf := strings.Contains(filter, "|")
+	matched := 0
+	fields := strings.FieldsFunc(filter, func(r rune) bool { return r == ',' || r == '|' })
+	for _, f := range fields {
+		if n, err := strconv.Atoi(f); err == nil && has[n] {
+			matched++
+		}
+	}
+	if !want {
+		return matched == 0
+	}
+	return matched == len(fields) || (anyOf && matched > 0)
+}
+
+const emptyFind = `{"movie_results":[],"person_results":[],"tv_results":[],"tv_episode_results":[],"tv_season_results":[]}`
+
+// fixtureName maps a TMDB path onto its fixture file, so
+// "/movie/550/watch/providers" is served from movie_550_watch_providers.json.
+func fixtureName(path string) string {
+	return strings.ReplaceAll(strings.Trim(path, "/"), "/", "_") + ".json"
+}
+
+// filterResults keeps the items of a paginated response for which keep
+// returns true, adjusting the totals to match.
+func filterResults(body []byte, keep func(map[string]any) bool) []byte {
+	var page map[string]any
+	if err := json.Unmarshal(body, &page); err != nil {
+		return body
+	}
+	items, _ := page["results"].([]any)
+	kept := []any{}
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok && keep(m) {
+			kept = append(kept, m)
+		}
+	}
+	page["results"] = kept
+	page["total_results"] = len(kept)
+	b, err := json.Marshal(page)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// statusBodies mirrors the error bodies TMDB sends for each status.
+var statusBodies = map[int]struct {
+	code    int
+	message string
+}{
+	http.StatusUnauthorized:        {7, "Invalid API key: You must be granted a valid key."},
+	http.StatusNotFound:            {34, "The resource you requested could not be found."},
+	http.StatusTooManyRequests:     {25, "Your request count (#) is over the allowed limit of (40)."},
+	http.StatusInternalServerError: {11, "Internal error: Something went wrong, contact TMDB."},
+	http.StatusBadGateway:          {24, "Your request to the backend server timed out. Try again."},
+	http.StatusServiceUnavailable:  {9, "Service offline: This service is temporarily offline, try again later."},
+	http.StatusGatewayTimeout:      {24, "Your request to the backend server timed out. Try again."},
+}
+
+func sendStatus(w http.ResponseWriter, status int) {
+	body := statusBodies[status]
+	if body.message == "" {
+		body.message = http.StatusText(status)
+	}
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"success":        false,
+		"status_code":    body.code,
+		"status_message": body.message,
+	})
+}
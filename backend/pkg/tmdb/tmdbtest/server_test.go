@@ -0,0 +1,83 @@
+package tmdbtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, srv *Server, path string) (*http.Response, map[string]any) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp, body
+}
+
+func TestServerFiltersResults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, body := get(t, srv, "/search/multi?query=BREAKING")
+	if got := body["total_results"]; got != float64(1) {
+		t.Fatalf("search total_results = %v, want 1", got)
+	}
+
+	for query, want := range map[string]float64{
+		"with_genres=878":                         2,
+		"with_genres=28|53":                       4,
+		"with_genres=28,878&without_genres=12":    1,
+		"with_genres=878&vote_average.gte=8.3":    1,
+		"without_genres=18,28&vote_average.gte=8": 1,
+	} {
+		_, body = get(t, srv, "/discover/movie?"+query)
+		if got := body["total_results"]; got != want {
+			t.Errorf("discover %s: total_results = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestServerFaultsApplyInOrder(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.FailNext(1, http.StatusBadGateway)
+	srv.RateLimitNext(1, 1500*time.Millisecond)
+
+	resp, _ := get(t, srv, "/movie/550")
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("first status = %d, want 502", resp.StatusCode)
+	}
+	resp, body := get(t, srv, "/movie/550")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+		t.Fatalf("second status = %d, Retry-After %q; want 429 and 2", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	if body["status_code"] != float64(25) {
+		t.Fatalf("status_code = %v, want TMDB's 25", body["status_code"])
+	}
+	resp, _ = get(t, srv, "/movie/550")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("third status = %d, want 200", resp.StatusCode)
+	}
+	if got := srv.Hits("/movie/550"); got != 3 {
+		t.Fatalf("hits = %d, want 3", got)
+	}
+}
+
+func TestServerUnknownResource(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	resp, body := get(t, srv, "/movie/1")
+	if resp.StatusCode != http.StatusNotFound || body["status_code"] != float64(34) {
+		t.Fatalf("got %d %v, want TMDB's 404", resp.StatusCode, body)
+	}
+}
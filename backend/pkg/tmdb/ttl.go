@@ -0,0 +1,78 @@
+package tmdb
+
+import (
+	"path"
+	"time"
+)
+
+// TTLRule caches responses for endpoints matching Pattern for TTL.
+// Patterns use path.Match syntax, so "*" matches exactly one path segment:
+// "/genre/*/list" matches "/genre/movie/list".
+type TTLRule struct {
+	Pattern string
+	TTL     time.Duration
+}
+
+// TTLPolicy decides how long each endpoint's responses are cached. The
+// first matching rule wins; endpoints matching none use Default. A
+// non-positive TTL disables caching for that endpoint.
+type TTLPolicy struct {
+	Default time.Duration
+	Rules   []TTLRule
+}
+
+// DefaultTTLPolicy caches slow-moving reference data for much longer than
+// lists that change through the day.
+func DefaultTTLPolicy() TTLPolicy {
+	return TTLPolicy{
+		Default: defaultCacheTTL,
+		Rules: []TTLRule{
+			{Pattern: "/genre/*/list", TTL: 24 * time.Hour},
+			{Pattern: "/certification/*/list", TTL: 24 * time.Hour},
+			{Pattern: "/trending/*/*", TTL: 10 * time.Minute},
+			{Pattern: "/search/*", TTL: 5 * time.Minute},
+			{Pattern: "/discover/*", TTL: 30 * time.Minute},
+			{Pattern: "/*/*/watch/providers", TTL: time.Hour},
+			{Pattern: "/*/*/recommendations", TTL: time.Hour},
+			{Pattern: "/*/*/similar", TTL: time.Hour},
+			{Pattern: "/*/*/reviews", TTL: time.Hour},
+			{Pattern: "/*/*/keywords", TTL: 24 * time.Hour},
+			{Pattern: "/*/*/release_dates", TTL: 24 * time.Hour},
+			{Pattern: "/*/*/content_ratings", TTL: 24 * time.Hour},
+			{Pattern: "/*/*/external_ids", TTL: 24 * time.Hour},
+			{Pattern: "/find/*", TTL: 24 * time.Hour},
+			{Pattern: "/*/*/credits", TTL: 6 * time.Hour},
+			{Pattern: "/*/*/combined_credits", TTL: 6 * time.Hour},
+			{Pattern: "/*/*/images", TTL: 6 * time.Hour},
+			{Pattern: "/*/*/videos", TTL: 6 * time.Hour},
+			{Pattern: "/movie/now_playing", TTL: 30 * time.Minute},
+			{Pattern: "/movie/upcoming", TTL: 30 * time.Minute},
+			{Pattern: "/tv/airing_today", TTL: 30 * time.Minute},
+			{Pattern: "/tv/on_the_air", TTL: 30 * time.Minute},
+			{Pattern: "/*/top_rated", TTL: 6 * time.Hour},
+			{Pattern: "/tv/*/season/*", TTL: time.Hour},
+			{Pattern: "/tv/*/season/*/episode/*", TTL: time.Hour},
+			{Pattern: "/movie/*", TTL: time.Hour},
+			{Pattern: "/tv/*", TTL: time.Hour},
+			{Pattern: "/person/*", TTL: time.Hour},
+			{Pattern: "/collection/*", TTL: 6 * time.Hour},
+		},
+	}
+}
+
+// TTL returns how long responses from endpoint should be cached.
+func (p TTLPolicy) TTL(endpoint string) time.Duration {
+	for _, rule := range p.Rules {
+		if ok, _ := path.Match(rule.Pattern, endpoint); ok {
+			return rule.TTL
+		}
+	}
+	return p.Default
+}
+
+// WithTTLPolicy replaces the client's per-endpoint cache lifetimes.
+func WithTTLPolicy(p TTLPolicy) Option {
+	return func(c *Client) {
+		c.ttl = p
+	}
+}
@@ -0,0 +1,75 @@
+package tmdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultTTLPolicy(t *testing.T) {
+	policy := DefaultTTLPolicy()
+
+	tests := []struct {
+		endpoint string
+		want     time.Duration
+	}{
+		{"/genre/movie/list", 24 * time.Hour},
+		{"/certification/tv/list", 24 * time.Hour},
+		{"/trending/movie/week", 10 * time.Minute},
+		{"/search/movie", 5 * time.Minute},
+		{"/discover/tv", 30 * time.Minute},
+		// Sub-resources match their own rule ahead of the details rule.
+		{"/movie/550", time.Hour},
+		{"/movie/550/keywords", 24 * time.Hour},
+		{"/movie/550/similar", time.Hour},
+		{"/movie/550/credits", 6 * time.Hour},
+		{"/tv/1399/content_ratings", 24 * time.Hour},
+		{"/person/287/external_ids", 24 * time.Hour},
+		{"/tv/1399/watch/providers", time.Hour},
+		{"/person/287", time.Hour},
+		{"/person/287/combined_credits", 6 * time.Hour},
+		// Curated lists look like details, so their rules come first.
+		{"/movie/now_playing", 30 * time.Minute},
+		{"/tv/top_rated", 6 * time.Hour},
+		{"/tv/1399/season/1/episode/1", time.Hour},
+		{"/collection/230", 6 * time.Hour},
+		{"/find/tt0137523", 24 * time.Hour},
+		{"/configuration", policy.Default},
+		{"/movie/550/lists", policy.Default},
+	}
+	for _, tt := range tests {
+		if got := policy.TTL(tt.endpoint); got != tt.want {
+			t.Errorf("TTL(%q) = %v, want %v", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestTTLPolicyFirstMatchWins(t *testing.T) {
+	policy := TTLPolicy{
+		Default: time.Minute,
+		Rules: []TTLRule{
+			{Pattern: "/movie/550", TTL: 0},
+			{Pattern: "/movie/*", TTL: time.Hour},
+			{Pattern: "/movie/550", TTL: 2 * time.Hour},
+		},
+	}
+	for endpoint, want := range map[string]time.Duration{
+		"/movie/550":         0,
+		"/movie/551":         time.Hour,
+		"/movie/550/credits": time.Minute,
+	} {
+		if got := policy.TTL(endpoint); got != want {
+			t.Errorf("TTL(%q) = %v, want %v", endpoint, got, want)
+		}
+	}
+}
+
+func TestWithCacheTTLReplacesRules(t *testing.T) {
+	c := NewClient("token", WithCacheTTL(time.Minute))
+	defer c.Close()
+
+	for _, endpoint := range []string{"/genre/movie/list", "/movie/550", "/configuration"} {
+		if got := c.ttl.TTL(endpoint); got != time.Minute {
+			t.Errorf("TTL(%q) = %v, want %v", endpoint, got, time.Minute)
+		}
+	}
+}
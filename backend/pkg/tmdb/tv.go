@@ -0,0 +1,166 @@
+package tmdb
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+type TVDetails struct {
+	ID               int             `json:"id"`
+	Name             string          `json:"name"`
+	OriginalName     string          `json:"original_name"`
+	Overview         string          `json:"overview"`
+	Tagline          string          `json:"tagline"`
+	PosterPath       string          `json:"poster_path"`
+	BackdropPath     string          `json:"backdrop_path"`
+	Homepage         string          `json:"homepage"`
+	Genres           []Genre         `json:"genres"`
+	OriginalLanguage string          `json:"original_language"`
+	OriginCountry    []string        `json:"origin_country"`
+	VoteAverage      float64         `json:"vote_average"`
+	VoteCount        int             `json:"vote_count"`
+	FirstAirDate     string          `json:"first_air_date"`
+	LastAirDate      string          `json:"last_air_date"`
+	EpisodeRunTime   []int           `json:"episode_run_time"`
+	NumberOfSeasons  int             `json:"number_of_seasons"`
+	NumberOfEpisodes int             `json:"number_of_episodes"`
+	Seasons          []SeasonSummary `json:"seasons"`
+	Networks         []Network       `json:"networks"`
+	CreatedBy        []Creator       `json:"created_by"`
+	// Status is e.g. "Returning Series", "Ended" or "Canceled"; Type is
+	// e.g. "Scripted", "Miniseries" or "Reality".
+	Status       string `json:"status"`
+	Type         string `json:"type"`
+	InProduction bool   `json:"in_production"`
+	// LastEpisodeToAir and NextEpisodeToAir are nil when there is no such
+	// episode, e.g. before a premiere or after a finale.
+	LastEpisodeToAir *Episode `json:"last_episode_to_air"`
+	NextEpisodeToAir *Episode `json:"next_episode_to_air"`
+	Appended
+}
+
+type Network struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	LogoPath      string `json:"logo_path"`
+	OriginCountry string `json:"origin_country"`
+}
+
+type Creator struct {
+	ID          int    `json:"id"`
+	CreditID    string `json:"credit_id"`
+	Name        string `json:"name"`
+	ProfilePath string `json:"profile_path"`
+}
+
+// SeasonSummary describes a season as listed in a series' details.
+// Season 0 holds specials.
+type SeasonSummary struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Overview     string  `json:"overview"`
+	AirDate      string  `json:"air_date"`
+	EpisodeCount int     `json:"episode_count"`
+	PosterPath   string  `json:"poster_path"`
+	SeasonNumber int     `json:"season_number"`
+	VoteAverage  float64 `json:"vote_average"`
+}
+
+type Season struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Overview     string    `json:"overview"`
+	AirDate      string    `json:"air_date"`
+	PosterPath   string    `json:"poster_path"`
+	SeasonNumber int       `json:"season_number"`
+	VoteAverage  float64   `json:"vote_average"`
+	Episodes     []Episode `json:"episodes"`
+}
+
+type Episode struct {
+	ID            int     `json:"id"`
+	ShowID        int     `json:"show_id"`
+	Name          string  `json:"name"`
+	Overview      string  `json:"overview"`
+	AirDate       string  `json:"air_date"`
+	SeasonNumber  int     `json:"season_number"`
+	EpisodeNumber int     `json:"episode_number"`
+	EpisodeType   string  `json:"episode_type"`
+	Runtime       int     `json:"runtime"`
+	StillPath     string  `json:"still_path"`
+	VoteAverage   float64 `json:"vote_average"`
+	VoteCount     int     `json:"vote_count"`
+	// Crew and GuestStars are only filled in for episodes listed in a
+	// Season or fetched with GetTVEpisode.
+	Crew       []CrewMember `json:"crew,omitempty"`
+	GuestStars []CastMember `json:"guest_stars,omitempty"`
+}
+
+// EpisodeDetails is an episode together with its full credits and stills.
+type EpisodeDetails struct {
+	Episode
+	Credits EpisodeCredits `json:"credits"`
+	Images  EpisodeImages  `json:"images"`
+}
+
+type EpisodeCredits struct {
+	Cast       []CastMember `json:"cast"`
+	Crew       []CrewMember `json:"crew"`
+	GuestStars []CastMember `json:"guest_stars"`
+}
+
+type EpisodeImages struct {
+	Stills []Image `json:"stills"`
+}
+
+// GetTVDetails returns a series' details, together with any of the
+// Append* sections named in appends.
+func (c *Client) GetTVDetails(ctx context.Context, id string, loc Locale, appends ...string) (*TVDetails, error) {
+	var response TVDetails
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+	if err := setAppend(params, loc, appends); err != nil {
+		return nil, err
+	}
+
+	err := c.get(ctx, "/tv/"+id, params, &response)
+	if err != nil {
+		return nil, err
+	}
+	response.forRegion(loc.Region)
+	return &response, nil
+}
+
+func (c *Client) GetTVSeason(ctx context.Context, id string, season int, loc Locale) (*Season, error) {
+	var response Season
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+
+	err := c.get(ctx, "/tv/"+id+"/season/"+strconv.Itoa(season), params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetTVEpisode returns an episode with its credits and stills, fetched in a
+// single request.
+func (c *Client) GetTVEpisode(ctx context.Context, id string, season, episode int, loc Locale) (*EpisodeDetails, error) {
+	var response EpisodeDetails
+	loc = c.locale(loc)
+	params := url.Values{}
+	params.Set("language", loc.Language)
+	params.Set("append_to_response", "credits,images")
+	// Include textless stills, which suit every language.
+	params.Set("include_image_language", primaryLanguage(loc.Language)+",null")
+
+	endpoint := "/tv/" + id + "/season/" + strconv.Itoa(season) + "/episode/" + strconv.Itoa(episode)
+	err := c.get(ctx, endpoint, params, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}